@@ -0,0 +1,34 @@
+package recode
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// qrAlphanumeric is the QR code alphanumeric mode charset,
+// space is also allowed by QR, but it separates words
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ$%*+-./:"
+
+// NewAlphanumericDictionary creates a Recoder for QR friendly mnemonics.
+// QR codes are much denser in alphanumeric mode, which has only
+// uppercase letters, digits and " $%*+-./:" characters.
+// Every word should consist of these characters, e.g. uppercased bip39 words.
+// Decoding is case insensitive, so mnemonics typed in lowercase are fine,
+// and EncodeString output could be put into QR in alphanumeric mode as is.
+// Words are trimmed as by NewDictionary before the check.
+func NewAlphanumericDictionary(words []string, opts ...Option) (Recoder, error) {
+	for i, word := range words {
+		// only trimmed words get into a mnemonic, normalization
+		// is applied for lookups only
+		word = strings.TrimSpace(word)
+		if j := strings.IndexFunc(word, func(r rune) bool {
+			return !strings.ContainsRune(qrAlphanumeric, r)
+		}); j >= 0 {
+			r, _ := utf8.DecodeRuneInString(word[j:])
+			return nil, fmt.Errorf("word %q at %d contains %q, which is not QR alphanumeric", word, i, r)
+		}
+	}
+
+	return NewDictionary(words, append([]Option{WithCaseInsensitive()}, opts...)...)
+}
@@ -0,0 +1,53 @@
+package recode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAlphanumericDictionary(t *testing.T) {
+	words := make([]string, len(Bip39Dictionary))
+	for i, word := range Bip39Dictionary {
+		words[i] = strings.ToUpper(word)
+	}
+
+	d, err := asDictionary(NewAlphanumericDictionary(words))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	phrase, err := d.EncodeString(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "LOBSTER AMONG WAY LEMON EXTRA ACTOR BETRAY", phrase)
+
+	decoded, err := d.DecodeString(phrase)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	decoded, err = d.DecodeString(strings.ToLower(phrase))
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = NewAlphanumericDictionary([]string{"0", "1", "$%*+", "-./:"})
+	assert.NoError(t, err)
+
+	// trimmed as by NewDictionary
+	d, err = asDictionary(NewAlphanumericDictionary([]string{" APPLE", "BANANA\t", "CHERRY", "DATE"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"APPLE", "BANANA", "CHERRY", "DATE"}, d.Words())
+}
+
+func TestNewAlphanumericDictionary_Error(t *testing.T) {
+	_, err := NewAlphanumericDictionary([]string{"FOO", "BAR", "Fizz", "BUZZ"})
+	assert.EqualError(t, err, `word "Fizz" at 2 contains 'i', which is not QR alphanumeric`)
+
+	_, err = NewAlphanumericDictionary([]string{"FOO", "BAR", "FIZZ", "BÜZZ"})
+	assert.EqualError(t, err, `word "BÜZZ" at 3 contains 'Ü', which is not QR alphanumeric`)
+
+	_, err = NewAlphanumericDictionary([]string{"FOO", "BAR", "FIZZ", "BU#Z"})
+	assert.Error(t, err)
+
+	_, err = NewAlphanumericDictionary(Bip39Dictionary)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package recode
+
+// EncodeBatch encodes every record as Encode does.
+// Mnemonics share a single backing array, so there is
+// one allocation for all the words instead of one per record.
+// On failure a *BatchError with the index of the failed record is returned.
+func (d *Dictionary) EncodeBatch(records [][]byte) ([][]string, error) {
+	total := 0
+	for _, r := range records {
+		total += d.EncodedLen(len(r))
+	}
+
+	words := make([]string, 0, total)
+	mnemonics := make([][]string, len(records))
+	for i, r := range records {
+		start := len(words)
+
+		var err error
+		words, err = d.appendVerified(words, r)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+
+		mnemonics[i] = words[start:len(words):len(words)]
+	}
+
+	return mnemonics, nil
+}
+
+// DecodeBatch decodes every mnemonic as Decode does.
+// Decoded records share a single backing array.
+// On failure a *BatchError with the index of the failed mnemonic is returned.
+func (d *Dictionary) DecodeBatch(mnemonics [][]string) ([][]byte, error) {
+	lens := make([]int, len(mnemonics))
+	total := 0
+	for i, m := range mnemonics {
+		if err := d.checkDecodeWork(m); err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+
+		n, err := d.DecodedLen(m)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+
+		lens[i] = n
+		total += n
+	}
+
+	buf := make([]byte, total)
+	records := make([][]byte, len(mnemonics))
+	for i, m := range mnemonics {
+		dst := buf[:lens[i]:lens[i]]
+		buf = buf[lens[i]:]
+
+		res, err := d.decodeInto(m, dst, false)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+		if !res.ChecksumValid {
+			return nil, &BatchError{Index: i, Err: ErrInvalidChecksum}
+		}
+
+		records[i], err = d.config.unwrapPayload(res.Data)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+	}
+
+	return records, nil
+}
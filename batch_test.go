@@ -0,0 +1,78 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_Batch(t *testing.T) {
+	for _, opts := range [][]Option{
+		nil,
+		{WithVersion(1)},
+		{WithChecksumWords(2)},
+	} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, opts...))
+		assert.NoError(t, err)
+
+		records := [][]byte{{}, randomBytes(t, 1), randomBytes(t, 16), randomBytes(t, 33)}
+
+		mnemonics, err := d.EncodeBatch(records)
+		assert.NoError(t, err)
+		assert.Len(t, mnemonics, len(records))
+		for i, r := range records {
+			want, err := d.Encode(r)
+			assert.NoError(t, err)
+			assert.Equal(t, want, mnemonics[i])
+		}
+
+		decoded, err := d.DecodeBatch(mnemonics)
+		assert.NoError(t, err)
+		assert.Equal(t, records, decoded)
+
+		// records do not overlap
+		decoded[1] = append(decoded[1], 0xff)
+		assert.Equal(t, records[2], decoded[2])
+	}
+}
+
+func TestDic_Batch_Empty(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonics, err := d.EncodeBatch(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, mnemonics)
+
+	records, err := d.DecodeBatch(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestDic_Batch_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithWholeBytesOnly()))
+	assert.NoError(t, err)
+
+	_, err = d.EncodeBatch([][]byte{randomBytes(t, 16), randomBytes(t, 3)})
+	var batchErr *BatchError
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.ErrorIs(t, err, ErrNotAligned)
+
+	d, err = asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonics, err := d.EncodeBatch([][]byte{[]byte("first"), []byte("second"), []byte("third")})
+	assert.NoError(t, err)
+
+	mnemonics[2][1] = "zoo"
+	_, err = d.DecodeBatch(mnemonics)
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 2, batchErr.Index)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	mnemonics[0] = nil
+	_, err = d.DecodeBatch(mnemonics)
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 0, batchErr.Index)
+}
@@ -0,0 +1,297 @@
+package recode
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var fruits = []string{"🍇", "🍈", "🍉", "🍊", "🍋", "🍌", "🍍", "🥭", "🍎", "🍐", "🍑", "🍒", "🍓", "🫐", "🥝", "🍅", "🫒", "🥥", "🥑", "🍆", "🥔", "🥕", "🌽", "🌶️", "🫑", "🥒", "🥬", "🥦", "🧄", "🧅", "🥜", "🫘"}
+
+type benchDictionary struct {
+	name  string
+	words []string
+}
+
+func benchDictionaries() []benchDictionary {
+	return []benchDictionary{
+		{"words=2", sequentialWords(2)},
+		{"words=256", sequentialWords(256)},
+		{"words=2048", sequentialWords(2048)},
+		{"words=65536", sequentialWords(65536)},
+		{"bip39", Bip39Dictionary},
+		{"fruits", fruits},
+	}
+}
+
+var benchSizes = []struct {
+	name string
+	size int
+}{
+	{"1KB", 1 << 10},
+	{"1MB", 1 << 20},
+}
+
+func sequentialWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", i)
+	}
+
+	return words
+}
+
+func BenchmarkEncode(b *testing.B) {
+	for _, bd := range benchDictionaries() {
+		d, err := NewDictionary(bd.words)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, bs := range benchSizes {
+			data := randomBytes(b, bs.size)
+
+			b.Run(bd.name+"/"+bs.name, func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+
+				for b.Loop() {
+					if _, err := d.Encode(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for _, bd := range benchDictionaries() {
+		d, err := NewDictionary(bd.words)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, bs := range benchSizes {
+			data := randomBytes(b, bs.size)
+			mnemonic, err := d.Encode(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(bd.name+"/"+bs.name, func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+
+				for b.Loop() {
+					if _, err := d.Decode(mnemonic); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkEncode_8MB(b *testing.B) {
+	d, err := NewDictionary(Bip39Dictionary)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	data := randomBytes(b, 8<<20)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := d.Encode(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecode_Index(b *testing.B) {
+	data := randomBytes(b, 1024)
+	for _, bi := range []struct {
+		name string
+		opts []Option
+	}{
+		{"map", nil},
+		{"index", []Option{WithWordIndex()}},
+	} {
+		d, err := NewDictionary(Bip39Dictionary, bi.opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		mnemonic, err := d.Encode(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(bi.name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := d.Decode(mnemonic); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncode_Hash(b *testing.B) {
+	for _, bh := range []struct {
+		name string
+		opts []Option
+	}{
+		{"sha256", nil},
+		{"crc32", []Option{WithCRC32()}},
+	} {
+		d, err := NewDictionary(Bip39Dictionary, bh.opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, size := range []int{16, 32, 256} {
+			data := randomBytes(b, size)
+
+			b.Run(fmt.Sprintf("%s/%dB", bh.name, size), func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+
+				for b.Loop() {
+					if _, err := d.Encode(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records := make([][]byte, 1000)
+	for i := range records {
+		records[i] = randomBytes(b, 16)
+	}
+	mnemonics, err := d.EncodeBatch(records)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("encode/loop", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			for _, r := range records {
+				if _, err := d.Encode(r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("encode/batch", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			if _, err := d.EncodeBatch(records); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("decode/loop", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			for _, m := range mnemonics {
+				if _, err := d.Decode(m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("decode/batch", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			if _, err := d.DecodeBatch(mnemonics); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTokenize(b *testing.B) {
+	// words share prefixes, e.g. "1", "10" and "100"
+	prefixed := make([]string, 2048)
+	for i := range prefixed {
+		prefixed[i] = fmt.Sprintf("%x", i)
+	}
+
+	for _, bd := range []benchDictionary{
+		{"cjk", chineseWords(b)},
+		{"fruits", fruits},
+		{"prefixed", prefixed},
+	} {
+		d, err := asDictionary(NewDictionary(bd.words))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		mnemonic, err := d.Encode(randomBytes(b, 1024))
+		if err != nil {
+			b.Fatal(err)
+		}
+		s := strings.Join(mnemonic, "")
+
+		b.Run(bd.name, func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := d.Tokenize(s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncode_Parallel(b *testing.B) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	data := randomBytes(b, 16<<20)
+	for _, parallel := range []bool{false, true} {
+		b.Run(fmt.Sprintf("parallel=%t", parallel), func(b *testing.B) {
+			workers := 1
+			if parallel {
+				workers = runtime.GOMAXPROCS(0)
+			}
+
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := d.appendEncodedParallel(nil, data, len(data)*8, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
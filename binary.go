@@ -0,0 +1,9 @@
+package recode
+
+var BinaryDictionary = []string{"0", "1"}
+
+// NewBinaryDictionary creates a Recoder with BinaryDictionary.
+// Useful for debugging, as every word is one bit.
+func NewBinaryDictionary(opts ...Option) (Recoder, error) {
+	return NewDictionary(BinaryDictionary, opts...)
+}
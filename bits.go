@@ -0,0 +1,53 @@
+package recode
+
+import "fmt"
+
+// EncodeBits encodes exactly bitLen first bits of data,
+// the first bits of a byte are the high ones, or the low ones with LSBFirst.
+// The bit length is restored by DecodeBits with the tail length
+// stored in the first word.
+// Checksum covers (bitLen+7)/8 bytes of data with unused bits of the
+// last byte set to zero and, for a partial last byte, the number
+// of its bits, so the exact bit length is verified by DecodeBits.
+// For whole bytes it is the same as Encode.
+func (d *Dictionary) EncodeBits(data []byte, bitLen int) ([]string, error) {
+	if err := checkPayloadLen(len(data)); err != nil {
+		return nil, err
+	}
+	if bitLen < 0 || bitLen > len(data)*8 {
+		return nil, fmt.Errorf("invalid bit length %d for %d bytes", bitLen, len(data))
+	}
+
+	payload := make([]byte, (bitLen+7)/8)
+	copy(payload, data)
+	if rem := bitLen % 8; rem > 0 {
+		payload[len(payload)-1] &= d.config.orderBits(^byte(0xff >> rem))
+	}
+
+	return d.encodeBits(payload, bitLen)
+}
+
+// DecodeBits decodes mnemonic created by EncodeBits
+// and returns the data with its exact bit length.
+// Unused bits of the last byte are zero.
+func (d *Dictionary) DecodeBits(mnemonic []string) ([]byte, int, error) {
+	if err := d.checkDecodeWork(mnemonic); err != nil {
+		return nil, 0, err
+	}
+
+	bitLen, _, err := d.payloadBitsLen(mnemonic)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := d.decodeInto(mnemonic, make([]byte, (bitLen+7)/8), true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !res.ChecksumValid {
+		return nil, 0, ErrInvalidChecksum
+	}
+
+	return res.Data, res.BitLength, nil
+}
@@ -0,0 +1,94 @@
+package recode
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeBits(t *testing.T) {
+	tests := []struct {
+		name   string
+		words  []string
+		data   []byte
+		bitLen int
+		want   []byte
+	}{
+		{"12 bits", Bip39Dictionary, []byte{0xab, 0xcd}, 12, []byte{0xab, 0xc0}},
+		{"1 bit", Bip39Dictionary, []byte{0xff}, 1, []byte{0x80}},
+		{"zero bits", Bip39Dictionary, []byte{0xff}, 0, []byte{}},
+		{"whole bytes", Bip39Dictionary, []byte{7, 255, 1, 255, 40, 128, 42, 42}, 64, []byte{7, 255, 1, 255, 40, 128, 42, 42}},
+		{"binary", []string{"0", "1"}, []byte{0xab, 0xcd}, 13, []byte{0xab, 0xc8}},
+		{"fruits", fruits, []byte{0xab, 0xcd, 0xef}, 19, []byte{0xab, 0xcd, 0xe0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(tt.words))
+			assert.NoError(t, err)
+
+			mnemonic, err := d.EncodeBits(tt.data, tt.bitLen)
+			assert.NoError(t, err)
+
+			got, bitLen, err := d.DecodeBits(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.bitLen, bitLen)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDic_EncodeBits_SameAsEncode(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+
+	got, err := d.EncodeBits(data, len(data)*8)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+}
+
+func TestDic_EncodeBits_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	_, err = d.EncodeBits([]byte{1}, 9)
+	assert.Error(t, err)
+
+	_, err = d.EncodeBits([]byte{1}, -1)
+	assert.Error(t, err)
+}
+
+func TestDic_DecodeBits_InvalidChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.EncodeBits([]byte{0xab, 0xcd}, 12)
+	assert.NoError(t, err)
+
+	mnemonic[1] = Bip39Dictionary[0]
+	_, _, err = d.DecodeBits(mnemonic)
+	assert.Error(t, err)
+}
+
+func TestDic_DecodeBits_TamperedBitLen(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.EncodeBits([]byte{0xab, 0xc0}, 13)
+	assert.NoError(t, err)
+
+	// one bit less in the tail length, the padding is '1' bits,
+	// so the payload is still valid, only the checksum could catch it
+	idx := slices.Index(Bip39Dictionary, mnemonic[0])
+	assert.NotZero(t, idx&(1<<d.tailChecksumLen-1))
+	mnemonic[0] = Bip39Dictionary[idx-1]
+
+	_, _, err = d.DecodeBits(mnemonic)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	res, err := d.DecodeDetailed(mnemonic)
+	assert.NoError(t, err)
+	assert.False(t, res.ChecksumValid)
+}
@@ -0,0 +1,41 @@
+package recode
+
+// DictionaryBuilder collects words from several sources for NewDictionary.
+// Words are validated only once by Build.
+type DictionaryBuilder struct {
+	words []string
+	opts  []Option
+}
+
+// NewDictionaryBuilder returns an empty builder,
+// opts are passed to NewDictionary by Build.
+func NewDictionaryBuilder(opts ...Option) *DictionaryBuilder {
+	return &DictionaryBuilder{opts: opts}
+}
+
+// Add appends a word to the dictionary.
+func (b *DictionaryBuilder) Add(word string) *DictionaryBuilder {
+	b.words = append(b.words, word)
+
+	return b
+}
+
+// AddAll appends words to the dictionary.
+func (b *DictionaryBuilder) AddAll(words []string) *DictionaryBuilder {
+	b.words = append(b.words, words...)
+
+	return b
+}
+
+// Len returns how many words are added.
+func (b *DictionaryBuilder) Len() int {
+	return len(b.words)
+}
+
+// Build creates the dictionary with all the validations of NewDictionary,
+// the number of words should be a power of two.
+// Duplicates are reported as *DuplicateError, Duplicate.Second
+// is the position of the word in the order of Add and AddAll calls.
+func (b *DictionaryBuilder) Build() (Recoder, error) {
+	return NewDictionary(b.words, b.opts...)
+}
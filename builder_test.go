@@ -0,0 +1,43 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDictionaryBuilder(t *testing.T) {
+	b := NewDictionaryBuilder(WithCaseInsensitive())
+	b.AddAll(Bip39Dictionary[:1024]).
+		AddAll(Bip39Dictionary[1024:2046]).
+		Add(Bip39Dictionary[2046]).
+		Add(Bip39Dictionary[2047])
+	assert.Equal(t, 2048, b.Len())
+
+	d, err := asDictionary(b.Build())
+	assert.NoError(t, err)
+
+	bip, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+	assert.Equal(t, bip.Fingerprint(), d.Fingerprint())
+
+	decoded, err := d.Decode([]string{"FESTIVAL", "among", "way", "lemon", "extra", "actor", "betray"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7, 255, 1, 255, 40, 128, 42, 42}, decoded)
+}
+
+func TestDictionaryBuilder_Error(t *testing.T) {
+	_, err := NewDictionaryBuilder().Build()
+	assert.Error(t, err)
+
+	_, err = NewDictionaryBuilder().AddAll([]string{"foo", "bar", "fizz"}).Build()
+	assert.Error(t, err)
+
+	_, err = NewDictionaryBuilder().AddAll([]string{"foo", "bar"}).Add(" ").Add("buzz").Build()
+	assert.Error(t, err)
+
+	_, err = NewDictionaryBuilder().AddAll([]string{"foo", "bar", "fizz"}).Add("foo").Build()
+	var dupErr *DuplicateError
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, []Duplicate{{Word: "foo", First: 0, Second: 3}}, dupErr.Duplicates)
+}
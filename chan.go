@@ -0,0 +1,92 @@
+package recode
+
+import "context"
+
+// EncodeChan streams words of Encode(data) to the words channel
+// for pipeline stages consuming them concurrently.
+// The error channel gets at most one error, e.g. ctx.Err() on cancellation.
+// Both channels are closed when encoding is done, so range over words
+// and then read the error channel.
+func (d *Dictionary) EncodeChan(ctx context.Context, data []byte) (<-chan string, <-chan error) {
+	words := make(chan string)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(words)
+
+		for word, err := range d.EncodeSeq(data) {
+			if err != nil {
+				errc <- err
+
+				return
+			}
+
+			select {
+			case words <- word:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+
+				return
+			}
+		}
+	}()
+
+	return words, errc
+}
+
+// DecodeChan decodes words received from the words channel until it is closed.
+// The checksum covers all the data, so bytes are sent only after
+// the whole mnemonic is received and verified, invalid data is never sent.
+// The error channel gets at most one error, e.g. ctx.Err() on cancellation.
+// Both channels are closed when decoding is done.
+func (d *Dictionary) DecodeChan(ctx context.Context, words <-chan string) (<-chan byte, <-chan error) {
+	out := make(chan byte)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		mnemonic := []string{}
+	receive:
+		for {
+			select {
+			case word, ok := <-words:
+				if !ok {
+					break receive
+				}
+
+				mnemonic = append(mnemonic, word)
+				if err := d.checkDecodeWork(mnemonic); err != nil {
+					errc <- err
+
+					return
+				}
+			case <-ctx.Done():
+				errc <- ctx.Err()
+
+				return
+			}
+		}
+
+		data, err := d.decode(mnemonic)
+		if err != nil {
+			errc <- err
+
+			return
+		}
+
+		for _, b := range data {
+			select {
+			case out <- b:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
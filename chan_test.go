@@ -0,0 +1,90 @@
+package recode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeChan(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 100)
+	want, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	words, errc := d.EncodeChan(context.Background(), data)
+	got := []string{}
+	for word := range words {
+		got = append(got, word)
+	}
+	assert.NoError(t, <-errc)
+	assert.Equal(t, want, got)
+}
+
+func TestDic_EncodeChan_Cancel(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	words, errc := d.EncodeChan(ctx, randomBytes(t, 100))
+
+	<-words
+	cancel()
+
+	for range words {
+	}
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
+
+func TestDic_DecodeChan(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 100)
+	words, errc := d.EncodeChan(context.Background(), data)
+	out, decErrc := d.DecodeChan(context.Background(), words)
+
+	got := []byte{}
+	for b := range out {
+		got = append(got, b)
+	}
+	assert.NoError(t, <-decErrc)
+	assert.NoError(t, <-errc)
+	assert.Equal(t, data, got)
+}
+
+func TestDic_DecodeChan_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	words := make(chan string, 7)
+	for _, word := range []string{"fire", "among", "way", "lemon", "extra", "actor", "betray"} {
+		words <- word
+	}
+	close(words)
+
+	out, errc := d.DecodeChan(context.Background(), words)
+	for range out {
+		t.Fatal("invalid data should not be sent")
+	}
+	assert.ErrorIs(t, <-errc, ErrInvalidChecksum)
+}
+
+func TestDic_DecodeChan_Cancel(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	words := make(chan string)
+	out, errc := d.DecodeChan(ctx, words)
+
+	words <- "festival"
+	cancel()
+
+	for range out {
+	}
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
@@ -0,0 +1,137 @@
+package recode
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	compressionNone  byte = 0
+	compressionFlate byte = 1
+)
+
+// EncodeCompressed deflates data and encodes it with a small header
+// (compression flag + original length) in front of the payload.
+// The header is a part of the payload, so it is covered by the checksum.
+// If compression does not make the payload smaller, data is stored raw.
+// With WithCompression it is the same as Encode.
+func (d *Dictionary) EncodeCompressed(data []byte) ([]string, error) {
+	if d.config.compression {
+		return d.encodeVerified(data)
+	}
+
+	payload, err := compressPayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.encodeVerified(payload)
+}
+
+// DecodeCompressed decodes a mnemonic created with EncodeCompressed.
+func (d *Dictionary) DecodeCompressed(mnemonic []string) ([]byte, error) {
+	payload, err := d.decode(mnemonic)
+	if err != nil || d.config.compression {
+		return payload, err
+	}
+
+	return decompressPayload(payload)
+}
+
+// compress returns the payload of data, deflated with WithCompression
+func (c config) compress(data []byte) ([]byte, error) {
+	if !c.compression {
+		return data, nil
+	}
+
+	return compressPayload(data)
+}
+
+// decompress returns the data of payload, inflated with WithCompression
+func (c config) decompress(payload []byte) ([]byte, error) {
+	if !c.compression {
+		return payload, nil
+	}
+
+	return decompressPayload(payload)
+}
+
+func compressPayload(data []byte) ([]byte, error) {
+	header := make([]byte, 1, 1+binary.MaxVarintLen64)
+	header = binary.AppendUvarint(header, uint64(len(data)))
+
+	var buf bytes.Buffer
+	buf.Write(header)
+
+	fw, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = fw.Write(data); err != nil {
+		return nil, err
+	}
+	if err = fw.Close(); err != nil {
+		return nil, err
+	}
+
+	if buf.Len() < len(header)+len(data) {
+		compressed := buf.Bytes()
+		compressed[0] = compressionFlate
+
+		return compressed, nil
+	}
+
+	header[0] = compressionNone
+
+	return append(header, data...), nil
+}
+
+func decompressPayload(payload []byte) ([]byte, error) {
+	if len(payload) < 1 {
+		return nil, errors.New("compressed payload: missing header")
+	}
+
+	flag := payload[0]
+	size, n := binary.Uvarint(payload[1:])
+	if n <= 0 {
+		return nil, errors.New("compressed payload: invalid length")
+	}
+	body := payload[1+n:]
+
+	switch flag {
+	case compressionNone:
+		if uint64(len(body)) != size {
+			return nil, errors.New("compressed payload: length mismatch")
+		}
+
+		return body, nil
+	case compressionFlate:
+		if size >= math.MaxInt64 {
+			return nil, errors.New("compressed payload: invalid length")
+		}
+
+		fr := flate.NewReader(bytes.NewReader(body))
+
+		// read one byte more than expected to detect length mismatch
+		// without inflating unbounded data
+		data, err := io.ReadAll(io.LimitReader(fr, int64(size)+1))
+		if cerr := fr.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("compressed payload: %w", err)
+		}
+		if uint64(len(data)) != size {
+			return nil, errors.New("compressed payload: length mismatch")
+		}
+
+		return data, nil
+	default:
+		return nil, fmt.Errorf("compressed payload: unknown compression flag %d", flag)
+	}
+}
@@ -0,0 +1,231 @@
+package recode
+
+import (
+	"crypto/rand"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeCompressed(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"short", []byte("nice!")},
+		{"text", []byte(strings.Repeat("all work and no play makes jack a dull boy. ", 20))},
+		{"random", randomBytes(t, 256)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mnemonic, err := d.EncodeCompressed(tt.data)
+			assert.NoError(t, err)
+
+			decoded, err := d.DecodeCompressed(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.data, decoded)
+		})
+	}
+}
+
+func TestDic_EncodeCompressed_Shorter(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte(strings.Repeat("all work and no play makes jack a dull boy. ", 20))
+
+	plain, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	compressed, err := d.EncodeCompressed(data)
+	assert.NoError(t, err)
+
+	assert.Less(t, len(compressed), len(plain))
+}
+
+func TestDic_EncodeCompressed_Incompressible(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 256)
+
+	payload, err := compressPayload(data)
+	assert.NoError(t, err)
+	assert.Equal(t, compressionNone, payload[0])
+
+	plain, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	compressed, err := d.EncodeCompressed(data)
+	assert.NoError(t, err)
+
+	// only the header is added
+	assert.LessOrEqual(t, len(compressed), len(plain)+2)
+}
+
+func TestDic_DecodeCompressed_InvalidHeader(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"empty", []byte{}},
+		{"unknown flag", []byte{42, 0}},
+		{"raw length mismatch", []byte{compressionNone, 5, 'n', 'i'}},
+		{"broken flate", []byte{compressionFlate, 5, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mnemonic, err := d.Encode(tt.payload)
+			assert.NoError(t, err)
+
+			_, err = d.DecodeCompressed(mnemonic)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWithCompression(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCompression(), WithSelfVerify()))
+	assert.NoError(t, err)
+
+	plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, data := range [][]byte{
+		{},
+		[]byte("nice!"),
+		[]byte(strings.Repeat("all work and no play makes jack a dull boy. ", 20)),
+		randomBytes(t, 256),
+	} {
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		// the same as EncodeCompressed without the option
+		compressed, err := plain.EncodeCompressed(data)
+		assert.NoError(t, err)
+		assert.Equal(t, compressed, mnemonic)
+
+		decoded, err = d.DecodeCompressed(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		res, err := d.DecodeDetailed(mnemonic)
+		assert.NoError(t, err)
+		assert.True(t, res.ChecksumValid)
+		assert.Equal(t, data, res.Data)
+		assert.Equal(t, len(data)*8, res.BitLength)
+		assert.Equal(t, len(data) == 0, d.IsEmpty(mnemonic))
+
+		dst := make([]byte, len(data))
+		n, err := d.DecodeInto(mnemonic, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, data, dst[:n])
+
+		if len(data) > 0 {
+			_, err = d.DecodeInto(mnemonic, dst[:len(data)-1])
+			assert.Error(t, err)
+		}
+
+		decoded, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic, " ")))
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		decoded, err = io.ReadAll(d.DecodeReaderFromWords(slices.Values(mnemonic)))
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		seq := []string{}
+		for word, err := range d.EncodeSeq(data) {
+			assert.NoError(t, err)
+			seq = append(seq, word)
+		}
+		assert.Equal(t, mnemonic, seq)
+	}
+}
+
+func TestWithCompression_Batch(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCompression()))
+	assert.NoError(t, err)
+
+	records := [][]byte{
+		[]byte(strings.Repeat("nice! ", 50)),
+		{},
+		randomBytes(t, 32),
+	}
+
+	mnemonics, err := d.EncodeBatch(records)
+	assert.NoError(t, err)
+
+	decoded, err := d.DecodeBatch(mnemonics)
+	assert.NoError(t, err)
+	assert.Equal(t, records, decoded)
+
+	encoded, err := d.NewEncoder().Encode(records[0])
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonics[0], encoded)
+}
+
+func TestWithCompression_Incompressible(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary, WithCompression())
+	assert.NoError(t, err)
+
+	plain, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 256)
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	// stored raw behind the flag and the length
+	payload, err := plain.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, compressionNone, payload[0])
+	assert.Equal(t, data, payload[3:])
+
+	raw, err := plain.Encode(data)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(mnemonic), len(raw)+2)
+}
+
+func TestWithCompression_InvalidChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCompression()))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte(strings.Repeat("nice! ", 50)))
+	assert.NoError(t, err)
+
+	i := len(mnemonic) / 2
+	if mnemonic[i] == "zoo" {
+		mnemonic[i] = "abandon"
+	} else {
+		mnemonic[i] = "zoo"
+	}
+
+	_, err = d.Decode(mnemonic)
+	assert.Error(t, err)
+
+	_, err = io.ReadAll(d.DecodeReaderFromWords(slices.Values(mnemonic)))
+	assert.Error(t, err)
+}
+
+func randomBytes(t testing.TB, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	assert.NoError(t, err)
+
+	return b
+}
@@ -0,0 +1,59 @@
+package recode
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// EncodeFromHex encodes hex encoded data, whitespace in s is ignored.
+func (d *Dictionary) EncodeFromHex(s string) ([]string, error) {
+	data, err := hex.DecodeString(stripSpaces(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+
+	return d.encodeVerified(data)
+}
+
+// DecodeToHex decodes the mnemonic and returns hex encoded data.
+func (d *Dictionary) DecodeToHex(mnemonic []string) (string, error) {
+	data, err := d.decode(mnemonic)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
+// EncodeFromBase64 encodes standard base64 encoded data, whitespace in s is ignored.
+func (d *Dictionary) EncodeFromBase64(s string) ([]string, error) {
+	data, err := base64.StdEncoding.DecodeString(stripSpaces(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	return d.encodeVerified(data)
+}
+
+// DecodeToBase64 decodes the mnemonic and returns standard base64 encoded data.
+func (d *Dictionary) DecodeToBase64(mnemonic []string) (string, error) {
+	data, err := d.decode(mnemonic)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func stripSpaces(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, s)
+}
@@ -0,0 +1,64 @@
+package recode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeFromHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		wantErr bool
+	}{
+		{"clean", "07ff01ff2880 2a2a", false},
+		{"whitespace", " 07 ff 01 ff\n28 80\t2a 2a\n", false},
+		{"upper case", "07FF01FF28802A2A", false},
+		{"odd length", "07ff01ff28802a2", true},
+		{"invalid", "07ff01ff28802a2x", true},
+	}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.EncodeFromHex(tt.hex)
+			if tt.wantErr {
+				assert.Error(t, err)
+				// hex error is wrapped
+				assert.Error(t, errors.Unwrap(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+			h, err := d.DecodeToHex(got)
+			assert.NoError(t, err)
+			assert.Equal(t, "07ff01ff28802a2a", h)
+		})
+	}
+}
+
+func TestDic_EncodeFromBase64(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	got, err := d.EncodeFromBase64("B/8B/yiA\nKio=")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+	b, err := d.DecodeToBase64(got)
+	assert.NoError(t, err)
+	assert.Equal(t, "B/8B/yiAKio=", b)
+
+	_, err = d.EncodeFromBase64("B/8B/yiAKio")
+	assert.Error(t, err)
+
+	_, err = d.DecodeToBase64([]string{"fire", "among", "way", "lemon", "extra", "actor", "betray"})
+	assert.Error(t, err)
+
+	_, err = d.DecodeToHex([]string{"fire", "among", "way", "lemon", "extra", "actor", "betray"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package recode
+
+import "sync"
+
+var (
+	defaultBip39     Recoder
+	defaultBip39Once sync.Once
+)
+
+// DefaultBip39 returns a Recoder for Bip39Dictionary without options,
+// it is built once on the first call and shared by all callers.
+// Use it instead of NewDictionary(Bip39Dictionary) in hot paths.
+// The Recoder is read-only after construction and safe for concurrent use.
+func DefaultBip39() Recoder {
+	defaultBip39Once.Do(func() {
+		d, err := NewDictionary(Bip39Dictionary)
+		if err != nil {
+			panic("recode: invalid bip39 dictionary: " + err.Error())
+		}
+
+		defaultBip39 = d
+	})
+
+	return defaultBip39
+}
@@ -0,0 +1,40 @@
+package recode
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultBip39(t *testing.T) {
+	plain, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	want, err := plain.Encode(data)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	recoders := make([]Recoder, 8)
+	for i := range recoders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			recoders[i] = DefaultBip39()
+
+			mnemonic, err := recoders[i].Encode(data)
+			assert.NoError(t, err)
+			assert.Equal(t, want, mnemonic)
+
+			_, err = recoders[i].(*Dictionary).Tokenize("festivalamongway")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	for _, rec := range recoders {
+		assert.Same(t, recoders[0], rec)
+	}
+}
@@ -1,82 +1,324 @@
 package recode
 
 import (
-	"crypto/sha256"
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"maps"
 	"math"
 	"math/big"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-type dictionary struct {
-	bitsToWord    map[string]string
-	wordToBits    map[string]string
-	bitsToInt     map[string]int
+// Dictionary encodes data into mnemonics of its words and back,
+// it is read-only after construction and safe for concurrent use.
+type Dictionary struct {
+	words      []string
+	wordToBits map[string]string
+	bitsToInt  map[string]int
+	// optional word to index table, see WithWordIndex
+	index         *wordIndex
+	trie          *trie
+	trieOnce      *sync.Once
 	bitsBatchSize int
 	wordsChecksum []byte
 	checksumLen   int
 	// how many bit in checksum are for tail len
 	// bitsBatchSize = checksumLen + tailChecksumLen
 	tailChecksumLen int
+	// how many leading words hold the checksum, see WithChecksumWords
+	checksumWords int
+
+	config config
 }
 
+// Recoder is the core of Dictionary, e.g. to accept any encoder in tests.
+// All the other methods are on Dictionary, Recoders returned by
+// constructors of the package are *Dictionary:
+//
+//	rec, err := recode.NewDictionary(words)
+//	...
+//	d := rec.(*recode.Dictionary)
 type Recoder interface {
 	// Encode converts the input byte slice into a mnemonic.
+	// Empty or nil data gives a mnemonic of the checksum word(s) only, see IsEmpty.
 	Encode(data []byte) ([]string, error)
 
 	// Decode takes a mnemonic and returns the original byte slice.
+	// The mnemonic of empty data decodes to a non-nil empty slice,
+	// nil is returned only with an error.
 	Decode(mnemonic []string) ([]byte, error)
 }
 
 // NewDictionary creates a new Recoder instance using the provided slice of words.
 // Returns an error if there are any problems with the words.
-func NewDictionary(words []string) (Recoder, error) {
-	if len(words) < 2 || (len(words)&(len(words)-1)) != 0 {
-		return nil, errors.New("dictionary should be complete and len(words) == 2^N")
+// Behavior could be changed with options, see Option.
+// The Recoder is *Dictionary.
+func NewDictionary(words []string, opts ...Option) (Recoder, error) {
+	d, err := newDictionary(words, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	bitsBatchSize := int(math.Log2(float64(len(words))))
+	return d, nil
+}
 
-	bitsToWord := make(map[string]string, len(words))
-	wordToBits := make(map[string]string, len(words))
-	bitsToInt := make(map[string]int, len(words))
-	dups := make(map[string]bool, len(words))
-	h := sha256.New()
+// newDictionary is NewDictionary returning *Dictionary
+func newDictionary(words []string, opts ...Option) (*Dictionary, error) {
+	bitsBatchSize, err := BitsPerWord(len(words))
+	if err != nil {
+		return nil, err
+	}
 
-	for i, word := range words {
+	c := newConfig(opts)
+	// check before any allocation, the list could be huge
+	if err := c.checkMaxBits(bitsBatchSize); err != nil {
+		return nil, err
+	}
+
+	trimmed := make([]string, 0, len(words))
+	for _, word := range words {
 		word = strings.TrimSpace(word)
 		if word == "" {
 			return nil, errors.New("words should not be empty")
 		}
 
-		if dups[word] {
-			return nil, fmt.Errorf("dictionary has duplicate: %s", word)
+		trimmed = append(trimmed, word)
+	}
+
+	return buildDictionary(trimmed, bitsBatchSize, c)
+}
+
+// NewDictionaryTopN creates a new Recoder from the first 2^maxBits words,
+// e.g. from a word list sorted by frequency or desirability.
+// Words after them are ignored, so duplicates are checked only within the first 2^maxBits words.
+// Returns an error if there are fewer words.
+func NewDictionaryTopN(words []string, maxBits int, opts ...Option) (Recoder, error) {
+	if maxBits < 1 || maxBits >= strconv.IntSize-1 {
+		return nil, fmt.Errorf("invalid bits per word %d", maxBits)
+	}
+
+	size := 1 << maxBits
+	if len(words) < size {
+		return nil, fmt.Errorf("need at least %d words for %d bits per word, got %d", size, maxBits, len(words))
+	}
+
+	return NewDictionary(words[:size], opts...)
+}
+
+// buildDictionary creates all the mappings for already trimmed words
+func buildDictionary(words []string, bitsBatchSize int, c config) (*Dictionary, error) {
+	if err := c.checkMaxBits(bitsBatchSize); err != nil {
+		return nil, err
+	}
+
+	wordToBits := make(map[string]string, len(words))
+	bitsToInt := make(map[string]int, len(words))
+	if c.hash == nil {
+		return nil, fmt.Errorf("unknown hash %q", c.hashName)
+	}
+	h := c.hash()
+	if h.Size() < 2 {
+		return nil, errors.New("hash should be at least 2 bytes long")
+	}
+	var dups []Duplicate
+
+	for i, word := range words {
+		if c.validUTF8 && !utf8.ValidString(word) {
+			return nil, fmt.Errorf("word %q at %d is not valid utf-8, binary words are safe only for non-text transports", word, i)
+		}
+
+		key := c.normalize(word)
+		if sep, ok := c.findSeparator(word, key); ok {
+			return nil, fmt.Errorf("word %q at %d contains separator %s", word, i, sep)
+		}
+		if bits, ok := wordToBits[key]; ok {
+			dups = append(dups, Duplicate{Word: word, First: bitsToInt[bits], Second: i})
+			if c.stopOnFirstDuplicate {
+				return nil, &DuplicateError{Duplicates: dups}
+			}
+
+			continue
 		}
-		dups[word] = true
 
 		bitWord := idxToBitString(i, bitsBatchSize)
-		bitsToWord[bitWord] = word
-		wordToBits[word] = bitWord
+		wordToBits[key] = bitWord
 		bitsToInt[bitWord] = i
 
 		h.Write([]byte(word))
 	}
 
+	if len(dups) > 0 {
+		return nil, &DuplicateError{Duplicates: dups}
+	}
+
 	tailChecksumLen := tailBitsLenInChecksum(bitsBatchSize)
+	if c.wholeBytes {
+		tailChecksumLen = 0
+	}
 	checksumLen := bitsBatchSize - tailChecksumLen
 
-	return &dictionary{
-		bitsToWord:      bitsToWord,
+	if c.hasVersion && (c.version < 0 || c.version >= len(words)) {
+		return nil, fmt.Errorf("version %d is out of range [0, %d)", c.version, len(words))
+	}
+
+	checksumWords := max(1, c.checksumWords)
+	if c.checksumWords < 0 {
+		return nil, fmt.Errorf("invalid checksum words count %d", c.checksumWords)
+	}
+	if checksumWords > 1 {
+		if c.withoutChecksum {
+			return nil, errors.New("checksum words could not be used without checksum")
+		}
+
+		checksumBits := checksumLen + (checksumWords-1)*bitsBatchSize
+		if checksumBits > 64 || checksumBits > h.Size()*8 {
+			return nil, fmt.Errorf("checksum of %d bits is too long for %d bytes hash", checksumBits, h.Size())
+		}
+	}
+
+	// max tail length should fit into tailChecksumLen bits
+	if !c.wholeBytes && maxTailLen(bitsBatchSize) >= 1<<tailChecksumLen {
+		return nil, fmt.Errorf("tail length %d does not fit into %d bits", maxTailLen(bitsBatchSize), tailChecksumLen)
+	}
+
+	aliases, err := buildAliases(wordToBits, c)
+	if err != nil {
+		return nil, err
+	}
+	// aliases are looked up as any other word, so there is no cost without them
+	for key, target := range aliases {
+		wordToBits[key] = wordToBits[target]
+	}
+
+	var index *wordIndex
+	if c.wordIndex {
+		index = newWordIndex(words, aliases, c)
+	}
+
+	return &Dictionary{
+		words:           words,
 		wordToBits:      wordToBits,
 		bitsToInt:       bitsToInt,
+		index:           index,
+		trieOnce:        &sync.Once{},
 		bitsBatchSize:   bitsBatchSize,
 		wordsChecksum:   h.Sum(nil),
 		checksumLen:     checksumLen,
 		tailChecksumLen: tailChecksumLen,
+		checksumWords:   checksumWords,
+		config:          c,
 	}, nil
 }
 
+// findSeparator returns the quoted separator if the word or its normalized key contains it,
+// whitespace or the one set by WithSeparator
+func (c config) findSeparator(word, key string) (string, bool) {
+	if c.separator != "" {
+		if strings.Contains(word, c.separator) || strings.Contains(key, c.separator) {
+			return strconv.Quote(c.separator), true
+		}
+
+		return "", false
+	}
+
+	if sep := strings.IndexFunc(key, unicode.IsSpace); sep >= 0 {
+		r, _ := utf8.DecodeRuneInString(key[sep:])
+		return strconv.QuoteRune(r), true
+	}
+
+	return "", false
+}
+
+// buildAliases validates aliases of WithAliases
+// and maps normalized aliases to normalized canonical words
+func buildAliases(wordToBits map[string]string, c config) (map[string]string, error) {
+	if len(c.aliases) == 0 {
+		return nil, nil
+	}
+
+	aliases := make(map[string]string, len(c.aliases))
+	originals := make(map[string]string, len(c.aliases))
+	for _, alias := range slices.Sorted(maps.Keys(c.aliases)) {
+		canonical := c.aliases[alias]
+		key, target := c.normalize(alias), c.normalize(canonical)
+
+		if key == "" {
+			return nil, fmt.Errorf("alias %q is empty", alias)
+		}
+		if sep, ok := c.findSeparator(alias, key); ok {
+			return nil, fmt.Errorf("alias %q contains separator %s", alias, sep)
+		}
+		if _, ok := wordToBits[key]; ok {
+			return nil, fmt.Errorf("alias %q collides with a dictionary word", alias)
+		}
+		if prev, ok := originals[key]; ok {
+			return nil, fmt.Errorf("aliases %q and %q collide", prev, alias)
+		}
+		if _, ok := wordToBits[target]; !ok {
+			return nil, fmt.Errorf("alias %q target %q is not in the dictionary", alias, canonical)
+		}
+
+		aliases[key] = target
+		originals[key] = alias
+	}
+
+	return aliases, nil
+}
+
+// Words returns a copy of the dictionary words in index order.
+func (d *Dictionary) Words() []string {
+	return slices.Clone(d.words)
+}
+
+// IsPrefixFree reports whether no word is a prefix of another word.
+// Only prefix free dictionaries could be used to decode mnemonics
+// without separators between words.
+// Note that bip39 english is not prefix free, e.g. "act" and "action",
+// so it requires separators.
+// Words are compared normalized, as Tokenize matches them,
+// see WithCaseInsensitive.
+func (d *Dictionary) IsPrefixFree() bool {
+	sorted := make([]string, len(d.words))
+	for i, word := range d.words {
+		sorted[i] = d.config.normalize(word)
+	}
+	slices.Sort(sorted)
+
+	// if a is a prefix of b, then all the words between them
+	// are prefixed with a too, so it is enough to check neighbors
+	for i := 1; i < len(sorted); i++ {
+		if strings.HasPrefix(sorted[i], sorted[i-1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fingerprint returns hex encoded hash of the dictionary words.
+func (d *Dictionary) Fingerprint() string {
+	return hex.EncodeToString(d.wordsChecksum)
+}
+
+// maxTailLen returns the biggest number of payload bits in the last word,
+// a full last word is stored as 0
+func maxTailLen(bitsBatchSize int) int {
+	return bitsBatchSize - 1
+}
+
+// tailBitsLenInChecksum returns how many bits are needed to store tail length,
+// which is in range [0, maxTailLen].
+// For bitsBatchSize == 1 tail is always 0, so no bits are needed.
 func tailBitsLenInChecksum(bitsBatchSize int) int {
 	tailChecksumLen := 0
 	if bitsBatchSize > 1 {
@@ -98,7 +340,16 @@ func padByteSlice(slice []byte, length int) []byte {
 	return newSlice
 }
 
+// idxToBitString returns idx as a bitLen long bit string.
+// It panics if idx does not fit into bitLen bits, or bitLen > 16.
 func idxToBitString(idx int, bitLen int) string {
+	if bitLen < 0 || bitLen > 16 {
+		panic(fmt.Sprintf("recode: bit length %d out of range [0, 16]", bitLen))
+	}
+	if idx < 0 || idx >= 1<<bitLen {
+		panic(fmt.Sprintf("recode: index %d does not fit into %d bits", idx, bitLen))
+	}
+
 	n := big.NewInt(int64(idx))
 	b := padByteSlice(n.Bytes(), 2)
 
@@ -107,61 +358,739 @@ func idxToBitString(idx int, bitLen int) string {
 	return str[16-bitLen:]
 }
 
-func (d *dictionary) Encode(data []byte) ([]string, error) {
-	mnemonic := []string{}
+// Encode converts the input byte slice into a mnemonic.
+// Empty or nil data gives a mnemonic of the checksum word(s) only, see IsEmpty.
+func (d *Dictionary) Encode(data []byte) ([]string, error) {
+	if d.config.observer == nil {
+		return d.encodeVerified(data)
+	}
 
-	var bitsBuilder strings.Builder
-	for _, b := range data {
-		bitsBuilder.WriteString(fmt.Sprintf("%08b", b))
+	start := time.Now()
+	mnemonic, err := d.encodeVerified(data)
+	if err == nil {
+		d.config.observer.EncodeDone(len(mnemonic), len(data), time.Since(start))
 	}
 
-	cs, err := d.checksum(data)
+	return mnemonic, err
+}
+
+// encodeVerified is Encode without the observer
+func (d *Dictionary) encodeVerified(data []byte) ([]string, error) {
+	payload, err := d.config.wrapPayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonic, err := d.encode(payload)
 	if err != nil {
 		return mnemonic, err
 	}
 
-	bits := bitsBuilder.String()
+	if d.config.selfVerify {
+		decoded, err := d.decode(mnemonic)
+		if err != nil || !bytes.Equal(decoded, data) {
+			return nil, ErrSelfCheckFailed
+		}
+	}
+
+	return mnemonic, nil
+}
 
-	// how many bits we should take from last word
-	tailLen := len(bits) % d.bitsBatchSize
-	tailLenBits := idxToBitString(tailLen, d.bitsBatchSize)
-	tailLenBits = tailLenBits[len(tailLenBits)-d.tailChecksumLen:]
+// EncodeAppend appends the mnemonic of data to dst and returns the extended slice.
+// Like append, it reuses the capacity of dst when possible.
+// On error dst is returned unchanged.
+func (d *Dictionary) EncodeAppend(dst []string, data []byte) ([]string, error) {
+	if d.config.observer == nil {
+		return d.appendVerified(dst, data)
+	}
 
-	// add checksum at the begining
+	start := time.Now()
+	mnemonic, err := d.appendVerified(dst, data)
+	if err == nil {
+		d.config.observer.EncodeDone(len(mnemonic)-len(dst), len(data), time.Since(start))
+	}
+
+	return mnemonic, err
+}
+
+// appendVerified is EncodeAppend without the observer
+func (d *Dictionary) appendVerified(dst []string, data []byte) ([]string, error) {
+	payload, err := d.config.wrapPayload(data)
+	if err != nil {
+		return dst, err
+	}
+
+	mnemonic, err := d.appendEncoded(dst, payload, len(payload)*8)
+	if err != nil {
+		return dst, err
+	}
+
+	if d.config.selfVerify {
+		decoded, err := d.decode(mnemonic[len(dst):])
+		if err != nil || !bytes.Equal(decoded, data) {
+			return dst, ErrSelfCheckFailed
+		}
+	}
+
+	return mnemonic, nil
+}
+
+func (d *Dictionary) encode(data []byte) ([]string, error) {
+	return d.encodeBits(data, len(data)*8)
+}
+
+// encodeBits encodes first bitLen bits of data,
+// data should be exactly (bitLen+7)/8 bytes long with unused bits zeroed.
+func (d *Dictionary) encodeBits(data []byte, bitLen int) ([]string, error) {
+	if err := checkPayloadLen(len(data)); err != nil {
+		return nil, err
+	}
+
+	mnemonic := make([]string, 0, 1+(bitLen+d.bitsBatchSize-1)/d.bitsBatchSize)
+
+	return d.appendEncoded(mnemonic, data, bitLen)
+}
+
+// appendEncoded appends encoded words to mnemonic.
+// Words are taken directly from the data bits, so there is no
+// intermediate bit string and the payload is never copied.
+func (d *Dictionary) appendEncoded(mnemonic []string, data []byte, bitLen int) ([]string, error) {
+	if err := checkPayloadLen(len(data)); err != nil {
+		return mnemonic, err
+	}
+
+	if workers := encodeWorkers(len(data)); workers > 1 {
+		return d.appendEncodedParallel(mnemonic, data, bitLen, workers)
+	}
+
+	mnemonic, err := d.appendHeader(mnemonic, data, bitLen)
+	if err != nil {
+		return mnemonic, err
+	}
+
+	return d.appendPayload(mnemonic, data, bitLen), nil
+}
+
+// appendHeader appends version and checksum words to mnemonic.
+func (d *Dictionary) appendHeader(mnemonic []string, data []byte, bitLen int) ([]string, error) {
+	cs, extra, err := d.checksumIdx(data, bitLen)
+	if err != nil {
+		return mnemonic, err
+	}
+
+	if d.config.wholeBytes {
+		if err := d.checkWholeBytes(bitLen); err != nil {
+			return mnemonic, err
+		}
+	}
+
+	if d.config.hasVersion {
+		mnemonic = append(mnemonic, d.words[d.config.version])
+	}
+
+	// checksum goes first
 	// so when decoding we dont care about its paddings
-	bits = cs + tailLenBits + bits
+	mnemonic = append(mnemonic, d.words[d.firstWordIdx(cs, bitLen)])
+	for _, idx := range extra {
+		mnemonic = append(mnemonic, d.words[idx])
+	}
 
-	for i := 0; i < len(bits)-tailLen; i += d.bitsBatchSize {
-		lb := bits[i : i+d.bitsBatchSize]
-		word, ok := d.bitsToWord[lb]
-		if !ok {
-			return mnemonic, fmt.Errorf("bits-to-word mapping not found for bits: %s", lb)
+	return mnemonic, nil
+}
+
+// appendPayload appends words of the first bitLen bits of data to mnemonic,
+// the last word is padded with ones.
+func (d *Dictionary) appendPayload(mnemonic []string, data []byte, bitLen int) []string {
+	mask := uint64(1)<<d.bitsBatchSize - 1
+	var acc uint64
+	accLen := 0
+	emitted := 0
+	for _, b := range data {
+		acc = acc<<8 | uint64(d.config.orderBits(b))
+		accLen += 8
+
+		for accLen >= d.bitsBatchSize && emitted+d.bitsBatchSize <= bitLen {
+			accLen -= d.bitsBatchSize
+			emitted += d.bitsBatchSize
+			mnemonic = append(mnemonic, d.words[acc>>accLen&mask])
 		}
+	}
+
+	// pad the tail with ones up to the whole word
+	if tailLen := bitLen % d.bitsBatchSize; tailLen > 0 {
+		padLen := d.bitsBatchSize - tailLen
+		tail := (acc>>(accLen-tailLen))<<padLen | (1<<padLen - 1)
+		mnemonic = append(mnemonic, d.words[tail&mask])
+	}
+
+	return mnemonic
+}
+
+// Decode takes a mnemonic and returns the original byte slice.
+// The mnemonic of empty data decodes to a non-nil empty slice,
+// nil is returned only with an error.
+func (d *Dictionary) Decode(mnemonic []string) ([]byte, error) {
+	if d.config.observer == nil {
+		return d.decode(mnemonic)
+	}
+
+	start := time.Now()
+	data, err := d.decode(mnemonic)
+	if err != nil {
+		d.config.observer.DecodeError(err)
+	} else {
+		d.config.observer.DecodeDone(len(mnemonic), len(data), time.Since(start))
+	}
+
+	return data, err
+}
+
+// decode is Decode without the observer
+func (d *Dictionary) decode(mnemonic []string) ([]byte, error) {
+	res, err := d.DecodeDetailed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.ChecksumValid {
+		return nil, ErrInvalidChecksum
+	}
+
+	return res.Data, nil
+}
+
+// IsEmpty reports whether the mnemonic is valid and encodes zero bytes,
+// i.e. it has no payload words and the checksum matches empty data.
+func (d *Dictionary) IsEmpty(mnemonic []string) bool {
+	res, err := d.DecodeDetailed(mnemonic)
+
+	return err == nil && res.ChecksumValid && res.BitLength == 0
+}
+
+// DecodeWithChecksum decodes a mnemonic stored in two parts,
+// the checksum word and the data words after it.
+// With WithVersion the version word of the dictionary is assumed.
+// The checksum word is validated first, then the usual Decode errors
+// are returned, e.g. ErrInvalidChecksum if the parts do not match.
+func (d *Dictionary) DecodeWithChecksum(checksumWord string, dataWords []string) ([]byte, error) {
+	if _, _, err := d.parseFirstWord(checksumWord); err != nil {
+		return nil, fmt.Errorf("checksum word: %w", err)
+	}
+
+	mnemonic := make([]string, 0, 2+len(dataWords))
+	if d.config.hasVersion {
+		mnemonic = append(mnemonic, d.words[d.config.version])
+	}
+	mnemonic = append(mnemonic, checksumWord)
+	mnemonic = append(mnemonic, dataWords...)
+
+	return d.decode(mnemonic)
+}
+
+// DecodeResult is the result of DecodeDetailed.
+type DecodeResult struct {
+	// Data is the decoded byte slice, only whole bytes are included
+	Data []byte
+	// BitLength is the number of payload bits in the mnemonic,
+	// with WithCompression or WithPayloadPrefix it is the number of bits of Data
+	BitLength int
+	// ChecksumValid reports whether the checksum matches Data
+	ChecksumValid bool
+	// TailLen is the number of payload bits in the last word,
+	// 0 means the last word is full
+	TailLen int
+	// LastWordPaddingBits is the number of padding bits in the last word,
+	// bitsPerWord - TailLen or 0 if the last word is full
+	LastWordPaddingBits int
+}
+
+// DecodeDetailed decodes the mnemonic and reports framing details.
+// Checksum mismatch is not an error, see DecodeResult.ChecksumValid,
+// the data is returned anyway, so during recovery caller could decide
+// whether to trust it. With WithCompression and WithPayloadPrefix
+// only the data of a valid checksum is inflated and stripped.
+func (d *Dictionary) DecodeDetailed(mnemonic []string) (DecodeResult, error) {
+	res, err := d.decodeRaw(mnemonic)
+	if err != nil || !d.config.wrapsPayload() || !res.ChecksumValid {
+		return res, err
+	}
+
+	res.Data, err = d.config.unwrapPayload(res.Data)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	res.BitLength = len(res.Data) * 8
+
+	return res, nil
+}
+
+// decodeRaw is DecodeDetailed without inflating and stripping the payload
+func (d *Dictionary) decodeRaw(mnemonic []string) (DecodeResult, error) {
+	if err := d.checkDecodeWork(mnemonic); err != nil {
+		return DecodeResult{}, err
+	}
+
+	n, err := d.DecodedLen(mnemonic)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+
+	return d.decodeInto(mnemonic, make([]byte, n), false)
+}
+
+// DecodedLen returns the length of the byte slice the mnemonic decodes to,
+// e.g. to size dst for DecodeInto, from the word count and the tail length
+// in the first word. Only the first word is validated.
+func (d *Dictionary) DecodedLen(mnemonic []string) (int, error) {
+	bitsLen, _, err := d.payloadBitsLen(mnemonic)
+	if err != nil {
+		return 0, err
+	}
+
+	return bitsLen / 8, nil
+}
+
+// EntropyBits returns the number of payload bits of the mnemonic,
+// e.g. 128 for a 16 bytes wallet, without decoding it.
+// It is calculated from the word count and the tail length in the first word,
+// only the first word is validated, so the checksum is not verified.
+func (d *Dictionary) EntropyBits(mnemonic []string) (int, error) {
+	bitsLen, _, err := d.payloadBitsLen(mnemonic)
+
+	return bitsLen, err
+}
+
+// payloadBitsLen returns the number of payload bits and the tail length of mnemonic.
+// Only the first word is validated.
+func (d *Dictionary) payloadBitsLen(mnemonic []string) (int, int, error) {
+	payload, err := d.stripVersion(mnemonic)
+	if err != nil {
+		return 0, 0, d.checkWrongDictionary(mnemonic, err)
+	}
+	mnemonic = payload
+
+	if len(mnemonic) == 0 {
+		return 0, 0, errors.New("empty mnemonic")
+	}
+
+	_, tailLen, err := d.parseFirstWord(mnemonic[0])
+	if err != nil {
+		return 0, 0, d.checkWrongDictionary(mnemonic, err)
+	}
+
+	if len(mnemonic) < d.checksumWords {
+		return 0, 0, errors.New("mnemonic is too short for the checksum")
+	}
 
-		mnemonic = append(mnemonic, word)
+	bitsLen, err := d.payloadBits(len(mnemonic)-d.checksumWords, tailLen)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return bitsLen, bitsLen % d.bitsBatchSize, nil
+}
+
+// payloadBits returns the number of payload bits in payloadWords words
+// with tailLen payload bits in the last one
+func (d *Dictionary) payloadBits(payloadWords, tailLen int) (int, error) {
+	if payloadWords > d.maxPayloadWords() {
+		return 0, ErrPayloadTooLarge
+	}
+
+	bitsLen := payloadWords * d.bitsBatchSize
+
+	if d.config.wholeBytes {
+		// tail is not stored, but the encoder guarantees
+		// that padding is shorter than a byte, see checkWholeBytes
+		return bitsLen / 8 * 8, nil
 	}
 
 	if tailLen > 0 {
-		tailBits := bits[len(bits)-tailLen:]
-		tailBits += strings.Repeat("1", d.bitsBatchSize-tailLen)
-		tailWord, ok := d.bitsToWord[tailBits]
+		bitsLen -= d.bitsBatchSize - tailLen
+	}
+	// tail length from the first word could be corrupted,
+	// so that there are no words to take the tail from
+	if bitsLen < 0 {
+		return 0, ErrInvalidTail
+	}
+
+	return bitsLen, nil
+}
+
+// paddingBits returns the number of padding bits in the last word with tailLen payload bits
+func (d *Dictionary) paddingBits(tailLen int) int {
+	if tailLen == 0 {
+		return 0
+	}
+
+	return d.bitsBatchSize - tailLen
+}
+
+// firstWordIdx returns index of the checksum word for bitLen bits of data
+func (d *Dictionary) firstWordIdx(cs, bitLen int) int {
+	if d.config.wholeBytes {
+		return cs
+	}
+
+	// for 2 words dictionary (bitsBatchSize == 1) every bit is a word,
+	// so tailLen is always 0 and the first word is the checksum only
+	return cs<<d.tailChecksumLen | bitLen%d.bitsBatchSize
+}
+
+// checkWholeBytes checks that bitLen bits could be encoded without the tail length,
+// see WithWholeBytesOnly
+func (d *Dictionary) checkWholeBytes(bitLen int) error {
+	if bitLen%8 != 0 {
+		return fmt.Errorf("%w: %d bits is not whole bytes", ErrNotAligned, bitLen)
+	}
+
+	if padding := (d.bitsBatchSize - bitLen%d.bitsBatchSize) % d.bitsBatchSize; padding >= 8 {
+		return fmt.Errorf("%w: %d bytes need %d bits of padding", ErrNotAligned, bitLen/8, padding)
+	}
+
+	return nil
+}
+
+// DecodeInto decodes the mnemonic into dst and returns the number of bytes written.
+// Returns an error if dst is too small, see DecodedLen.
+// With WithCompression or WithPayloadPrefix the data is decoded
+// into a new slice and copied to dst.
+func (d *Dictionary) DecodeInto(mnemonic []string, dst []byte) (int, error) {
+	if err := d.checkDecodeWork(mnemonic); err != nil {
+		return 0, err
+	}
+
+	if d.config.wrapsPayload() {
+		data, err := d.decode(mnemonic)
+		if err != nil {
+			return 0, err
+		}
+		if len(dst) < len(data) {
+			return 0, fmt.Errorf("dst is too small: %d < %d", len(dst), len(data))
+		}
+
+		return copy(dst, data), nil
+	}
+
+	res, err := d.decodeInto(mnemonic, dst, false)
+	if err != nil {
+		return 0, err
+	}
+
+	if !res.ChecksumValid {
+		return 0, ErrInvalidChecksum
+	}
+
+	return len(res.Data), nil
+}
+
+// decodeHashChunk is how many decoded bytes are collected before feeding the hash
+const decodeHashChunk = 4096
+
+// decodeInto decodes mnemonic into dst,
+// if partial, the last incomplete byte is included and checksum covers it.
+func (d *Dictionary) decodeInto(mnemonic []string, dst []byte, partial bool) (DecodeResult, error) {
+	bitsLen, tailLen, err := d.payloadBitsLen(mnemonic)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+
+	n := bitsLen / 8
+	if partial {
+		n = (bitsLen + 7) / 8
+	} else if err := d.checkTrailingBits(bitsLen); err != nil {
+		return DecodeResult{}, err
+	}
+	if len(dst) < n {
+		return DecodeResult{}, fmt.Errorf("dst is too small: %d < %d", len(dst), n)
+	}
+
+	mnemonic, err = d.stripVersion(mnemonic)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+
+	checksum, _, err := d.parseFirstWord(mnemonic[0])
+	if err != nil {
+		return DecodeResult{}, err
+	}
+
+	return d.decodePayload(mnemonic, dst[:n], checksum, bitsLen, tailLen)
+}
+
+// decodePayload decodes bitsLen payload bits of mnemonic without the version word into dst,
+// dst length is the number of bytes to decode, the last one could be partial.
+// checksum is the checksum bits of the first word.
+func (d *Dictionary) decodePayload(mnemonic []string, dst []byte, checksum string, bitsLen, tailLen int) (DecodeResult, error) {
+	h := d.config.hash()
+	n := len(dst)
+	pos, hashed := 0, 0
+	remaining := bitsLen
+	var acc uint64
+	accLen := 0
+
+	for i := d.checksumWords; i < len(mnemonic); i++ {
+		idx, ok := d.lookupIdx(mnemonic[i])
 		if !ok {
-			return mnemonic, fmt.Errorf("bits-to-word mapping not found for tail bits: %s", tailBits)
+			return DecodeResult{}, d.checkWrongDictionary(mnemonic, errors.New("invalid mnemonic word"))
+		}
+
+		// the last word could be padded, take only its payload bits
+		take := min(d.bitsBatchSize, remaining)
+		remaining -= take
+		acc = acc<<take | uint64(idx>>(d.bitsBatchSize-take))
+		accLen += take
+
+		for accLen >= 8 && pos < n {
+			accLen -= 8
+			dst[pos] = d.config.orderBits(byte(acc >> accLen))
+			pos++
+		}
+		acc &= 1<<accLen - 1
+
+		// feed the hash in chunks while decoding,
+		// so no intermediate copy of the whole payload is kept
+		if pos-hashed >= decodeHashChunk {
+			if _, err := h.Write(dst[hashed:pos]); err != nil {
+				return DecodeResult{}, err
+			}
+			hashed = pos
 		}
-		mnemonic = append(mnemonic, tailWord)
 	}
 
-	return mnemonic, nil
+	if pos < n {
+		// partial byte, unused bits are zeroed
+		dst[pos] = d.config.orderBits(byte(acc << (8 - accLen)))
+		pos++
+	}
+
+	if _, err := h.Write(dst[hashed:pos]); err != nil {
+		return DecodeResult{}, err
+	}
+	sum, err := d.sumChecksum(h, min(bitsLen, n*8))
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	decodedChecksum := idxToBitString(d.checksumFromSum(sum), d.checksumLen)
+	extraValid, err := d.extraChecksumValid(sum, mnemonic[1:d.checksumWords])
+	if err != nil {
+		return DecodeResult{}, err
+	}
+
+	return DecodeResult{
+		Data:                dst,
+		BitLength:           bitsLen,
+		ChecksumValid:       d.config.withoutChecksum || checksumEqual(checksum, decodedChecksum) && extraValid,
+		TailLen:             tailLen,
+		LastWordPaddingBits: d.paddingBits(tailLen),
+	}, nil
+}
+
+// ChecksumBits returns how many bits of the first word are used for the checksum.
+// The rest of the first word stores the tail length:
+//
+//	bitsBatchSize = checksumLen + tailChecksumLen
+//
+// So the bigger the dictionary the stronger the checksum, e.g. 32 words
+// dictionary has 5 = 2 + 3, only 2 bits of checksum, and a random
+// corruption passes it with 1/4 probability.
+// For bip39 size dictionary it is 11 = 7 + 4, 1/128 probability.
+// Extra checksum words add all their bits, see WithChecksumWords.
+func (d *Dictionary) ChecksumBits() int {
+	if d.config.withoutChecksum {
+		return 0
+	}
+
+	return d.checksumLen + (d.checksumWords-1)*d.bitsBatchSize
+}
+
+// MaxTailLen returns the biggest tail length stored in the first word,
+// bitsPerWord - 1, as a full last word is stored as 0.
+// It always fits into bitsPerWord - ChecksumBits() bits of the first word,
+// so implementations in other languages could check their framing against it.
+// With WithWholeBytesOnly the tail length is not stored.
+func (d *Dictionary) MaxTailLen() int {
+	return maxTailLen(d.bitsBatchSize)
+}
+
+// lookupIdx returns index of the mnemonic word
+func (d *Dictionary) lookupIdx(word string) (int, bool) {
+	if d.index != nil {
+		return d.index.lookup(d.config.normalize(word))
+	}
+
+	bits, ok := d.lookup(word)
+	if !ok {
+		return 0, false
+	}
+
+	return d.bitsToInt[bits], true
+}
+
+// lookup returns bits of the mnemonic word
+func (d *Dictionary) lookup(word string) (string, bool) {
+	bits, ok := d.wordToBits[d.config.normalize(word)]
+
+	return bits, ok
 }
 
-func (d *dictionary) Decode(mnemonic []string) ([]byte, error) {
+// InspectFirstWord splits the first word of a mnemonic exactly as Decode does:
+// first ChecksumBits bits are the checksum, the rest is the length
+// of the payload in the last word, 0 means the last word is full.
+func (d *Dictionary) InspectFirstWord(word string) (string, int, error) {
+	return d.parseFirstWord(word)
+}
+
+// Contains reports whether the word is in the dictionary.
+// The word is normalized the same way as for Decode,
+// see WithCaseInsensitive and WithNormalization, and aliases are accepted, see WithAliases.
+func (d *Dictionary) Contains(word string) bool {
+	_, ok := d.lookupIdx(word)
+
+	return ok
+}
+
+// UnknownWords returns indexes of the mnemonic words not in the dictionary,
+// e.g. to highlight them in a UI before Decode. Words are checked as by Contains.
+// It returns an empty slice if all the words are known.
+func (d *Dictionary) UnknownWords(mnemonic []string) []int {
+	unknown := []int{}
+	for i, word := range mnemonic {
+		if !d.Contains(word) {
+			unknown = append(unknown, i)
+		}
+	}
+
+	return unknown
+}
+
+// Canonicalize returns the mnemonic as Encode emits it, e.g. to store
+// a clean copy of recovery input. Words are resolved as by Decode,
+// see WithCaseInsensitive, WithNormalization and WithAliases,
+// but the checksum is not verified.
+// Returns an error on the first unknown word.
+func (d *Dictionary) Canonicalize(mnemonic []string) ([]string, error) {
+	canonical := make([]string, len(mnemonic))
+	for i, word := range mnemonic {
+		idx, ok := d.lookupIdx(word)
+		if !ok {
+			return nil, d.checkWrongDictionary(mnemonic, fmt.Errorf("unknown word %q at %d", word, i))
+		}
+
+		canonical[i] = d.words[idx]
+	}
+
+	return canonical, nil
+}
+
+// IsChecksumWord reports whether the word could be the first word of a mnemonic.
+func (d *Dictionary) IsChecksumWord(word string) bool {
+	_, _, err := d.parseFirstWord(word)
+
+	return err == nil
+}
+
+// PossibleChecksumWords returns all the first words with the checksum of data,
+// one for every valid tail length, ordered by tail length.
+// The first word of Encode(data) is one of them, so during interactive
+// entry of a known payload it could confirm the first word.
+// With WithWholeBytesOnly there is no tail length, so it is a single word.
+func (d *Dictionary) PossibleChecksumWords(data []byte) ([]string, error) {
+	cs, err := d.checksumValue(data, len(data)*8)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.config.wholeBytes {
+		return []string{d.words[cs]}, nil
+	}
+
+	words := make([]string, 0, d.bitsBatchSize)
+	for tailLen := range d.bitsBatchSize {
+		words = append(words, d.words[cs<<d.tailChecksumLen|tailLen])
+	}
+
+	return words, nil
+}
+
+// checkTrailingBits checks that whole bytes payload has no leftover bits,
+// see WithStrictLength
+func (d *Dictionary) checkTrailingBits(bitsLen int) error {
+	if d.config.strictLength && bitsLen%8 != 0 {
+		return fmt.Errorf("%w: %d bits left after %d bytes", ErrTrailingWords, bitsLen%8, bitsLen/8)
+	}
+
+	return nil
+}
+
+// wrongDictionaryUnknownRatio is the share of unknown words
+// above which ErrWrongDictionary is returned, see checkWrongDictionary
+const wrongDictionaryUnknownRatio = 0.5
+
+// checkWrongDictionary returns ErrWrongDictionary instead of err,
+// if more than half of the words are not in the dictionary,
+// as a mnemonic of another dictionary rather than a typo is more likely.
+// It is called only on errors, so there is no cost for valid mnemonics.
+func (d *Dictionary) checkWrongDictionary(mnemonic []string, err error) error {
+	unknown := 0
+	for _, word := range mnemonic {
+		if _, ok := d.lookupIdx(word); !ok {
+			unknown++
+		}
+	}
+
+	if float64(unknown) > wrongDictionaryUnknownRatio*float64(len(mnemonic)) {
+		return fmt.Errorf("%w: %d of %d words are unknown", ErrWrongDictionary, unknown, len(mnemonic))
+	}
+
+	return err
+}
+
+// checkDecodeWork checks that mnemonic fits into WithMaxDecodeWork limit
+func (d *Dictionary) checkDecodeWork(mnemonic []string) error {
+	if d.config.maxDecodeWork > 0 && len(mnemonic) > d.config.maxDecodeWork/d.bitsBatchSize {
+		return ErrDecodeTooExpensive
+	}
+
+	return nil
+}
+
+// stripVersion validates and removes the version word, see WithVersion
+func (d *Dictionary) stripVersion(mnemonic []string) ([]string, error) {
+	if !d.config.hasVersion {
+		return mnemonic, nil
+	}
+
 	if len(mnemonic) == 0 {
 		return nil, errors.New("empty mnemonic")
 	}
 
-	checksumTailBits, ok := d.wordToBits[mnemonic[0]]
+	if err := d.checkVersionWord(mnemonic[0]); err != nil {
+		return nil, err
+	}
+
+	return mnemonic[1:], nil
+}
+
+// checkVersionWord checks that word is the version word of the dictionary
+func (d *Dictionary) checkVersionWord(word string) error {
+	version, ok := d.lookupIdx(word)
 	if !ok {
-		return nil, errors.New("invalid mnemonic words")
+		return errors.New("invalid version word")
+	}
+
+	if version != d.config.version {
+		return &VersionError{Version: version, Expected: d.config.version}
+	}
+
+	return nil
+}
+
+// parseFirstWord splits the first word of mnemonic into checksum bits and tail length
+func (d *Dictionary) parseFirstWord(word string) (string, int, error) {
+	checksumTailBits, ok := d.lookup(word)
+	if !ok {
+		return "", 0, errors.New("invalid mnemonic words")
 	}
 
 	checksum, tailLenBits := checksumTailBits[:d.checksumLen], checksumTailBits[d.checksumLen:]
@@ -170,66 +1099,172 @@ func (d *dictionary) Decode(mnemonic []string) ([]byte, error) {
 	if d.tailChecksumLen > 0 {
 		tailLenBits = strings.Repeat("0", d.bitsBatchSize-d.tailChecksumLen) + tailLenBits
 		tailLen, ok = d.bitsToInt[tailLenBits]
-		if !ok {
-			return nil, errors.New("invalid tail")
+		if !ok || tailLen > d.MaxTailLen() {
+			return "", 0, ErrInvalidTail
 		}
 	}
 
-	var bitsBuilder strings.Builder
-	for i := 1; i < len(mnemonic); i++ {
-		wordBits, ok := d.wordToBits[mnemonic[i]]
-		if !ok {
-			return nil, errors.New("invalid mnemonic word")
-		}
-		bitsBuilder.WriteString(wordBits)
+	return checksum, tailLen, nil
+}
+
+// Checksum returns the checksum word Encode(data) starts with,
+// the version word is not included, see WithVersion.
+// It is a reference for the format implementations in other languages.
+// Index of the word in the dictionary is bitsPerWord bits long:
+//
+//	sum := sha256(data || wordsChecksum)
+//	checksum := first ChecksumBits() bits of sum, big endian
+//	tailLen := len(data) * 8 % bitsPerWord
+//	index := checksum << (bitsPerWord - ChecksumBits()) | tailLen
+//
+// where wordsChecksum is sha256 of all the words concatenated, see Fingerprint.
+// Hash could be changed with WithHash, then it is used for both sums,
+// and salted with WithDomain, then the salt goes after wordsChecksum.
+func (d *Dictionary) Checksum(data []byte) (string, error) {
+	cs, err := d.checksumValue(data, len(data)*8)
+	if err != nil {
+		return "", err
 	}
 
-	bitString := bitsBuilder.String()
-	if tailLen > 0 {
-		paddingLen := d.bitsBatchSize - tailLen
-		bitString = bitString[:len(bitString)-paddingLen]
+	return d.words[d.firstWordIdx(cs, len(data)*8)], nil
+}
+
+// checksum calculates bit string one word length
+func (d *Dictionary) checksum(data []byte) (string, error) {
+	cs, err := d.checksumValue(data, len(data)*8)
+	if err != nil {
+		return "", err
 	}
 
-	src := []byte(bitString)
-	dst := make([]byte, len(src)/8)
-	var bitMask byte = 1
+	return idxToBitString(cs, d.checksumLen), nil
+}
 
-	bitCounter := 0
-	for b := 0; b < len(bitString)/8; b++ {
-		for bit := 0; bit < 8; bit++ {
-			dst[b] |= (src[bitCounter] & bitMask) << (7 - bit)
-			bitCounter++
-		}
+// checksumValue returns first checksumLen bits of the checksum
+// of the first bitLen bits of data as int
+func (d *Dictionary) checksumValue(data []byte, bitLen int) (int, error) {
+	if d.config.withoutChecksum {
+		return 0, nil
 	}
 
-	decodedChecksum, err := d.checksum(dst)
+	sum, err := d.lastChecksum(data, bitLen)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if checksum != decodedChecksum {
-		return nil, errors.New("invalid checksum")
+	return d.checksumFromSum(sum), nil
+}
+
+// checksumIdx returns checksum bits of the first word
+// and indexes of the extra checksum words, see WithChecksumWords
+func (d *Dictionary) checksumIdx(data []byte, bitLen int) (int, []int, error) {
+	if d.checksumWords == 1 {
+		cs, err := d.checksumValue(data, bitLen)
+
+		return cs, nil, err
 	}
 
-	return dst, nil
+	sum, err := d.lastChecksum(data, bitLen)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	extra := make([]int, d.checksumWords-1)
+	for i := range extra {
+		extra[i] = d.extraChecksumIdx(sum, i)
+	}
+
+	return d.checksumFromSum(sum), extra, nil
 }
 
-// checksum calculates bit string one word length
-func (d *dictionary) checksum(data []byte) (string, error) {
-	h := sha256.New()
+// extraChecksumIdx returns index of i-th extra checksum word,
+// its bits follow the checksum bits of the first word in the hash sum
+func (d *Dictionary) extraChecksumIdx(sum []byte, i int) int {
+	offset := d.checksumLen + i*d.bitsBatchSize
+	idx := 0
+	for bit := offset; bit < offset+d.bitsBatchSize; bit++ {
+		idx = idx<<1 | int(sum[bit/8]>>(7-bit%8)&1)
+	}
+
+	return idx
+}
+
+// extraChecksumValid reports whether extra checksum words match the hash sum
+// in constant time, see checksumEqual
+func (d *Dictionary) extraChecksumValid(sum []byte, words []string) (bool, error) {
+	valid := 1
+	for i, word := range words {
+		idx, ok := d.lookupIdx(word)
+		if !ok {
+			return false, errors.New("invalid mnemonic word")
+		}
+
+		valid &= subtle.ConstantTimeEq(int32(idx), int32(d.extraChecksumIdx(sum, i)))
+	}
+
+	return valid == 1, nil
+}
+
+// checksumEqual compares stored and calculated checksum bit strings
+// in constant time, so a recovery endpoint does not leak through timing
+// how many leading checksum bits of a guess are right
+func checksumEqual(stored, calculated string) bool {
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(calculated)) == 1
+}
+
+// headerLen returns how many words go before the payload
+func (d *Dictionary) headerLen() int {
+	if d.config.hasVersion {
+		return d.checksumWords + 1
+	}
+
+	return d.checksumWords
+}
+
+// checksumFromSum returns first checksumLen bits of the hash sum as int
+func (d *Dictionary) checksumFromSum(sum []byte) int {
+	return int(uint16(sum[0])<<8|uint16(sum[1])) >> (16 - d.checksumLen)
+}
+
+// LastChecksum returns full hash(data || wordsChecksum || domain), sha256 by default,
+// the first checksumLen bits of it are stored in the first word of mnemonic.
+// Domain is empty unless it is set with WithDomain.
+func (d *Dictionary) LastChecksum(data []byte) ([]byte, error) {
+	return d.lastChecksum(data, len(data)*8)
+}
+
+// lastChecksum returns the hash sum of the first bitLen bits of data,
+// data should be exactly (bitLen+7)/8 bytes long with unused bits zeroed
+func (d *Dictionary) lastChecksum(data []byte, bitLen int) ([]byte, error) {
+	h := d.config.hash()
 	_, err := h.Write(data)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	_, err = h.Write(d.wordsChecksum)
+
+	return d.sumChecksum(h, bitLen)
+}
+
+// sumChecksum writes wordsChecksum and domain into h, which already has
+// hashedBits bits of data, and returns the sum.
+// If the last byte of data is partial, the number of its bits goes last,
+// so the bit length could not be changed without breaking the checksum.
+// Whole bytes add nothing, so their checksum is the same as of Encode.
+func (d *Dictionary) sumChecksum(h hash.Hash, hashedBits int) ([]byte, error) {
+	_, err := h.Write(d.wordsChecksum)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	_, err = h.Write(d.config.domain)
+	if err != nil {
+		return nil, err
+	}
+	if rem := hashedBits % 8; rem > 0 {
+		if _, err := h.Write([]byte{byte(rem)}); err != nil {
+			return nil, err
+		}
 	}
 
-	sum := h.Sum(nil)
-	str := fmt.Sprintf("%08b", sum[0]) + fmt.Sprintf("%08b", sum[1])
-
-	return str[:d.checksumLen], nil
+	return h.Sum(nil), nil
 }
 
-var _ Recoder = &dictionary{}
+var _ Recoder = &Dictionary{}
@@ -2,11 +2,16 @@ package recode
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"math"
 	r "math/rand/v2"
+	"slices"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -67,6 +72,16 @@ func TestNewError(t *testing.T) {
 			[]string{"foo", "bar", "buzz", "👍"},
 			false,
 		},
+		{
+			"space inside word",
+			[]string{"foo", "bar", "ice cream", "buzz"},
+			true,
+		},
+		{
+			"unicode space inside word",
+			[]string{"foo", "bar", "ice cream", "buzz"},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,6 +95,18 @@ func TestNewError(t *testing.T) {
 	}
 }
 
+// randomSeparatorFreeWord is randomWord with whitespace replaced,
+// words containing separators are rejected
+func randomSeparatorFreeWord() string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+
+		return r
+	}, randomWord())
+}
+
 func TestDic_Encode(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -250,7 +277,7 @@ func TestDic_Random(t *testing.T) {
 		wordsNum := int(math.Pow(2, float64(wordsExp)))
 		words := make([]string, 0, wordsNum)
 		for j := 0; j < wordsNum; j++ {
-			words = append(words, randomWord())
+			words = append(words, randomSeparatorFreeWord())
 		}
 
 		d, err := NewDictionary(words)
@@ -268,6 +295,45 @@ func TestDic_Random(t *testing.T) {
 	}
 }
 
+// TestDic_AllSizes pins tail length and checksum slicing for every dictionary size
+func TestDic_AllSizes(t *testing.T) {
+	for bits := 1; bits <= 16; bits++ {
+		d, err := asDictionary(NewDictionary(sequentialWords(1 << bits)))
+		assert.NoError(t, err)
+
+		// 8*n % bits repeats every bits bytes, so the max tail is among them
+		maxTailLen, maxTailBytes := 0, 1
+		for n := 1; n <= bits; n++ {
+			if tailLen := n * 8 % bits; tailLen > maxTailLen {
+				maxTailLen, maxTailBytes = tailLen, n
+			}
+		}
+
+		for _, size := range []int{0, 1, maxTailBytes} {
+			t.Run(fmt.Sprintf("bits=%d/bytes=%d", bits, size), func(t *testing.T) {
+				data := make([]byte, size)
+				for i := range data {
+					data[i] = byte(i*37 + bits)
+				}
+
+				mnemonic, err := d.Encode(data)
+				assert.NoError(t, err)
+				assert.Len(t, mnemonic, d.EncodedLen(size))
+
+				res, err := d.DecodeDetailed(mnemonic)
+				assert.NoError(t, err)
+				assert.True(t, res.ChecksumValid)
+				assert.Equal(t, data, res.Data)
+				assert.Equal(t, size*8, res.BitLength)
+				assert.Equal(t, size*8%bits, res.TailLen)
+				if size == maxTailBytes {
+					assert.Equal(t, maxTailLen, res.TailLen)
+				}
+			})
+		}
+	}
+}
+
 func randomWord() string {
 	l := r.IntN(32) + 32
 	b := make([]byte, l)
@@ -330,6 +396,19 @@ func Test_dictionary_idxToBitString(t *testing.T) {
 	}
 }
 
+func Test_dictionary_idxToBitString_Boundaries(t *testing.T) {
+	for bitLen := 1; bitLen <= 16; bitLen++ {
+		assert.Equal(t, strings.Repeat("1", bitLen), idxToBitString(1<<bitLen-1, bitLen))
+		assert.Equal(t, strings.Repeat("0", bitLen), idxToBitString(0, bitLen))
+
+		assert.Panics(t, func() { idxToBitString(1<<bitLen, bitLen) }, bitLen)
+		assert.Panics(t, func() { idxToBitString(-1, bitLen) }, bitLen)
+	}
+
+	assert.Panics(t, func() { idxToBitString(0, 17) })
+	assert.Panics(t, func() { idxToBitString(0, -1) })
+}
+
 func Test_tailBitsLenInChecksum(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -367,3 +446,880 @@ func Test_tailBitsLenInChecksum(t *testing.T) {
 		})
 	}
 }
+
+func TestDic_DecodeInto(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic := []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}
+	want := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+
+	l, err := d.DecodedLen(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, len(want), l)
+
+	// dirty buffer with extra space
+	dst := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	n, err := d.DecodeInto(mnemonic, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, len(want), n)
+	assert.Equal(t, want, dst[:n])
+
+	_, err = d.DecodeInto(mnemonic, make([]byte, len(want)-1))
+	assert.Error(t, err)
+
+	_, err = d.DecodeInto([]string{}, dst)
+	assert.Error(t, err)
+
+	_, err = d.DecodeInto([]string{"fire", "among", "way", "lemon", "extra", "actor", "betray"}, dst)
+	assert.Error(t, err)
+}
+
+func TestDic_DecodedLen(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for l := 0; l < 64; l++ {
+		mnemonic, err := d.Encode(make([]byte, l))
+		assert.NoError(t, err)
+
+		n, err := d.DecodedLen(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, l, n)
+	}
+
+	_, err = d.DecodedLen([]string{})
+	assert.Error(t, err)
+
+	_, err = d.DecodedLen([]string{"WTF"})
+	assert.Error(t, err)
+}
+
+func TestDic_DecodedLen_MatchesDecode(t *testing.T) {
+	for _, opts := range [][]Option{
+		nil,
+		{WithVersion(1)},
+		{WithChecksumWords(2)},
+		{WithoutChecksum()},
+	} {
+		for _, words := range [][]string{{"0", "1"}, sequentialWords(16), Bip39Dictionary, sequentialWords(65536)} {
+			d, err := asDictionary(NewDictionary(words, opts...))
+			assert.NoError(t, err)
+
+			for l := 0; l < 40; l++ {
+				mnemonic, err := d.Encode(randomBytes(t, l))
+				assert.NoError(t, err)
+
+				n, err := d.DecodedLen(mnemonic)
+				assert.NoError(t, err)
+
+				decoded, err := d.Decode(mnemonic)
+				assert.NoError(t, err)
+				assert.Len(t, decoded, n)
+			}
+		}
+	}
+}
+
+func TestNewBinaryDictionary(t *testing.T) {
+	d, err := NewBinaryDictionary()
+	assert.NoError(t, err)
+
+	got, err := d.Encode([]byte("42"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "0", "0", "1", "1", "0", "1", "0", "0", "0", "0", "1", "1", "0", "0", "1", "0"}, got)
+
+	dec, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("42"), dec)
+}
+
+func TestNewHexDictionary(t *testing.T) {
+	d, err := NewHexDictionary()
+	assert.NoError(t, err)
+
+	got, err := d.Encode([]byte("42"))
+	assert.NoError(t, err)
+	assert.Len(t, got, 5)
+	// data words are plain hex
+	assert.Equal(t, []string{"3", "4", "3", "2"}, got[1:])
+
+	dec, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("42"), dec)
+
+	for l := 0; l < 16; l++ {
+		data := randomBytes(t, l)
+		got, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		dec, err := d.Decode(got)
+		assert.NoError(t, err)
+		assert.Equal(t, data, dec)
+	}
+}
+
+func TestDic_LastChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte("nice!")
+
+	words := sha256.New()
+	for _, w := range Bip39Dictionary {
+		words.Write([]byte(w))
+	}
+	want := sha256.Sum256(append(data, words.Sum(nil)...))
+
+	got, err := d.LastChecksum(data)
+	assert.NoError(t, err)
+	assert.Equal(t, want[:], got)
+
+	// first word of mnemonic starts with checksum bits
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%08b", got[0])[:7], d.wordToBits[mnemonic[0]][:7])
+}
+
+func TestDic_Words(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", " bar", "fizz\t", "buzz"}))
+	assert.NoError(t, err)
+
+	words := d.Words()
+	assert.Equal(t, []string{"foo", "bar", "fizz", "buzz"}, words)
+
+	// copy does not affect dictionary
+	words[0] = "WTF"
+	assert.Equal(t, "foo", d.Words()[0])
+
+	d, err = asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	restored, err := asDictionary(NewDictionary(d.Words()))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	restoredMnemonic, err := restored.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, restoredMnemonic)
+}
+
+func TestNewDictionary_Duplicates(t *testing.T) {
+	words := []string{"foo", "bar", "fizz", "fizz", "foo", "buzz", "fizz", "one"}
+
+	_, err := NewDictionary(words)
+	var dupErr *DuplicateError
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, []Duplicate{
+		{Word: "fizz", First: 2, Second: 3},
+		{Word: "foo", First: 0, Second: 4},
+		{Word: "fizz", First: 2, Second: 6},
+	}, dupErr.Duplicates)
+	assert.EqualError(t, err, `dictionary has duplicates: "fizz" at 2 and 3, "foo" at 0 and 4, "fizz" at 2 and 6`)
+
+	_, err = NewDictionary(words, WithStopOnFirstDuplicate())
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, []Duplicate{{Word: "fizz", First: 2, Second: 3}}, dupErr.Duplicates)
+}
+
+func TestDic_IsChecksumWord(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	assert.True(t, d.IsChecksumWord("festival"))
+	assert.True(t, d.IsChecksumWord("abandon"))
+	assert.False(t, d.IsChecksumWord("WTF"))
+	assert.False(t, d.IsChecksumWord(""))
+
+	// tail length 15 > 10 is impossible for 11 bits words
+	assert.False(t, d.IsChecksumWord(Bip39Dictionary[0b00000001111]))
+	assert.True(t, d.IsChecksumWord(Bip39Dictionary[0b00000001010]))
+}
+
+func TestDic_PossibleChecksumWords(t *testing.T) {
+	for _, tt := range []struct {
+		words []string
+		opts  []Option
+		want  int
+	}{
+		{Bip39Dictionary, nil, 11},
+		{fruits, nil, 5},
+		{BinaryDictionary, nil, 1},
+		{Bip39Dictionary, []Option{WithWholeBytesOnly()}, 1},
+		{Bip39Dictionary, []Option{WithVersion(2)}, 11},
+	} {
+		d, err := asDictionary(NewDictionary(tt.words, tt.opts...))
+		assert.NoError(t, err)
+
+		for l := range 20 {
+			data := randomBytes(t, l)
+
+			words, err := d.PossibleChecksumWords(data)
+			assert.NoError(t, err)
+			assert.Len(t, words, tt.want)
+
+			for _, word := range words {
+				assert.True(t, d.IsChecksumWord(word), word)
+			}
+
+			checksumWord, err := d.Checksum(data)
+			assert.NoError(t, err)
+			assert.Contains(t, words, checksumWord)
+		}
+	}
+
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	words, err := d.PossibleChecksumWords([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "festival", words[64%11])
+}
+
+func TestDic_Contains(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	assert.True(t, d.Contains("festival"))
+	assert.True(t, d.Contains("zoo"))
+	assert.False(t, d.Contains("Festival"))
+	assert.False(t, d.Contains("fest"))
+	assert.False(t, d.Contains(""))
+
+	ci, err := asDictionary(NewDictionary(Bip39Dictionary, WithCaseInsensitive(), WithWordIndex()))
+	assert.NoError(t, err)
+	assert.True(t, ci.Contains("Festival"))
+	assert.False(t, ci.Contains("fest"))
+
+	norm, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithNormalization(func(s string) string {
+		return strings.TrimSuffix(s, "!")
+	})))
+	assert.NoError(t, err)
+	assert.True(t, norm.Contains("fizz!"))
+}
+
+func TestDic_Canonicalize(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary,
+		WithCaseInsensitive(),
+		WithNormalization(func(s string) string { return strings.Trim(s, ".,") }),
+		WithAliases(map[string]string{"betrai": "betray"}),
+	))
+	assert.NoError(t, err)
+
+	messy := []string{"FESTIVAL", "Among,", "way.", "lemon", "EXTRA", "actor", "Betrai"}
+	canonical, err := d.Canonicalize(messy)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, canonical)
+
+	want, err := d.Decode(messy)
+	assert.NoError(t, err)
+
+	decoded, err := d.Decode(canonical)
+	assert.NoError(t, err)
+	assert.Equal(t, want, decoded)
+
+	// canonical words are decoded by a strict dictionary too
+	strict, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+	decoded, err = strict.Decode(canonical)
+	assert.NoError(t, err)
+	assert.Equal(t, want, decoded)
+
+	// checksum is not verified
+	canonical, err = d.Canonicalize([]string{"Zoo", "zoo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zoo", "zoo"}, canonical)
+
+	_, err = d.Canonicalize([]string{"festival", "amog", "way"})
+	assert.EqualError(t, err, `unknown word "amog" at 1`)
+
+	_, err = d.Canonicalize([]string{"foo", "fizz", "way"})
+	assert.ErrorIs(t, err, ErrWrongDictionary)
+}
+
+func TestDic_UnknownWords(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic := []string{"festival", "amog", "way", "Lemon", "extra", "", "betray"}
+	assert.Equal(t, []int{1, 3, 5}, d.UnknownWords(mnemonic))
+	assert.Equal(t, []int{}, d.UnknownWords([]string{"festival", "among"}))
+	assert.Equal(t, []int{}, d.UnknownWords(nil))
+
+	ci, err := asDictionary(NewDictionary(Bip39Dictionary, WithCaseInsensitive()))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 5}, ci.UnknownWords(mnemonic))
+}
+
+func TestDic_TwoWords(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"0", "1"}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, d.bitsBatchSize)
+	assert.Equal(t, 1, d.checksumLen)
+	assert.Equal(t, 0, d.tailChecksumLen)
+
+	for _, l := range []int{0, 1, 7} {
+		data := randomBytes(t, l)
+
+		got, err := d.Encode(data)
+		assert.NoError(t, err)
+		// one bit of checksum and one word per bit
+		assert.Len(t, got, 1+l*8)
+
+		dec, err := d.Decode(got)
+		assert.NoError(t, err)
+		assert.Equal(t, data, dec)
+	}
+}
+
+func TestDic_DecodeDetailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    []string
+		mnemonic []string
+		want     DecodeResult
+	}{
+		{
+			"bip39",
+			Bip39Dictionary,
+			[]string{"festival", "among", "way", "lemon", "extra", "actor", "betray"},
+			DecodeResult{
+				Data:                []byte{7, 255, 1, 255, 40, 128, 42, 42},
+				BitLength:           64,
+				ChecksumValid:       true,
+				TailLen:             9,
+				LastWordPaddingBits: 2,
+			},
+		},
+		{
+			"empty data",
+			[]string{"foo", "bar", "fizz", "buzz"},
+			[]string{"fizz"},
+			DecodeResult{
+				Data:          []byte{},
+				BitLength:     0,
+				ChecksumValid: true,
+				TailLen:       0,
+			},
+		},
+		{
+			"full last word",
+			[]string{"foo", "bar", "fizz", "buzz"},
+			[]string{"fizz", "foo", "buzz", "foo", "bar"},
+			DecodeResult{
+				Data:          []byte("1"),
+				BitLength:     8,
+				ChecksumValid: true,
+				TailLen:       0,
+			},
+		},
+		{
+			"invalid checksum",
+			Bip39Dictionary,
+			[]string{"fire", "among", "way", "lemon", "extra", "actor", "betray"},
+			DecodeResult{
+				Data:                []byte{7, 255, 1, 255, 40, 128, 42, 42},
+				BitLength:           64,
+				ChecksumValid:       false,
+				TailLen:             9,
+				LastWordPaddingBits: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(tt.words))
+			assert.NoError(t, err)
+
+			got, err := d.DecodeDetailed(tt.mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDic_DecodeDetailed_Padding(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, fruits, BinaryDictionary, HexDictionary} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+		bits := d.bitsBatchSize
+
+		for l := 0; l < 24; l++ {
+			mnemonic, err := d.Encode(randomBytes(t, l))
+			assert.NoError(t, err)
+
+			res, err := d.DecodeDetailed(mnemonic)
+			assert.NoError(t, err)
+
+			// payload and padding bits fill all the payload words
+			assert.Equal(t, (len(mnemonic)-1)*bits, l*8+res.LastWordPaddingBits)
+			assert.Less(t, res.LastWordPaddingBits, bits)
+		}
+	}
+
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+	for l, want := range []int{0, 3, 6, 9, 1, 4, 7, 10, 2, 5, 8, 0} {
+		mnemonic, err := d.Encode(make([]byte, l))
+		assert.NoError(t, err)
+
+		res, err := d.DecodeDetailed(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, want, res.LastWordPaddingBits, "%d bytes", l)
+	}
+}
+
+func TestDic_DecodeWithChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got, err := d.DecodeWithChecksum("festival", []string{"among", "way", "lemon", "extra", "actor", "betray"})
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	_, err = d.DecodeWithChecksum("fire", []string{"among", "way", "lemon", "extra", "actor", "betray"})
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	// tail length 15 does not fit 11 bits word
+	_, err = d.DecodeWithChecksum(Bip39Dictionary[0b00000001111], []string{"among"})
+	assert.ErrorIs(t, err, ErrInvalidTail)
+
+	_, err = d.DecodeWithChecksum("WTF", []string{"among"})
+	assert.EqualError(t, err, "checksum word: invalid mnemonic words")
+
+	_, err = d.DecodeWithChecksum("festival", []string{"among", "WTF", "lemon", "extra", "actor", "betray"})
+	assert.Error(t, err)
+
+	got, err = d.DecodeWithChecksum("rose", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	v, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(5)))
+	assert.NoError(t, err)
+	mnemonic, err := v.Encode(data)
+	assert.NoError(t, err)
+
+	got, err = v.DecodeWithChecksum(mnemonic[1], mnemonic[2:])
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestDic_Empty(t *testing.T) {
+	for _, opts := range [][]Option{
+		nil,
+		{WithVersion(3)},
+		{WithChecksumWords(2)},
+		{WithWholeBytesOnly()},
+	} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, opts...))
+		assert.NoError(t, err)
+
+		mnemonic, err := d.Encode([]byte{})
+		assert.NoError(t, err)
+
+		fromNil, err := d.Encode(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, mnemonic, fromNil)
+		assert.True(t, d.IsEmpty(mnemonic))
+
+		data, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.NotNil(t, data)
+		assert.Empty(t, data)
+
+		n, err := d.DecodeInto(mnemonic, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		nonEmpty, err := d.Encode([]byte{0})
+		assert.NoError(t, err)
+		assert.False(t, d.IsEmpty(nonEmpty))
+	}
+}
+
+func TestDic_IsEmpty_Invalid(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	assert.False(t, d.IsEmpty(nil))
+	assert.False(t, d.IsEmpty([]string{"unknown"}))
+	// valid tail length, wrong checksum
+	assert.False(t, d.IsEmpty([]string{"abandon"}))
+
+	data, err := d.Decode([]string{"abandon"})
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	assert.Nil(t, data)
+}
+
+func TestDic_Decode_WrongDictionary(t *testing.T) {
+	bip39, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+	fruit, err := NewDictionary(fruits)
+	assert.NoError(t, err)
+
+	mnemonic, err := fruit.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	_, err = bip39.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrWrongDictionary)
+	assert.EqualError(t, err, "mnemonic is probably from another dictionary: 9 of 9 words are unknown")
+
+	versioned, err := NewDictionary(Bip39Dictionary, WithVersion(1))
+	assert.NoError(t, err)
+	_, err = versioned.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrWrongDictionary)
+}
+
+func TestDic_Decode_WrongDictionaryThreshold(t *testing.T) {
+	d, err := NewDictionary([]string{"foo", "bar", "fizz", "buzz"})
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fizz", "foo", "buzz", "foo", "bar"}, mnemonic)
+
+	// a typo is not a wrong dictionary
+	_, err = d.Decode([]string{"fizz", "foo", "buzz", "fo", "bar"})
+	assert.EqualError(t, err, "invalid mnemonic word")
+
+	// exactly half of the words is not enough
+	_, err = d.Decode([]string{"fizz", "foo", "bazz", "fo", "bor", "buzz"})
+	assert.NotErrorIs(t, err, ErrWrongDictionary)
+
+	_, err = d.Decode([]string{"fizz", "foo", "bazz", "fo", "bor"})
+	assert.ErrorIs(t, err, ErrWrongDictionary)
+
+	_, err = d.Decode([]string{"fuzz", "foo", "bazz", "fo", "bor"})
+	assert.ErrorIs(t, err, ErrWrongDictionary)
+}
+
+func TestDic_Decode_TamperedTail(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rose"}, mnemonic)
+
+	for i := 1; i < 16; i++ {
+		// keep checksum bits, change tail length bits
+		idx := d.bitsToInt[d.wordToBits["rose"]]&^0b1111 | i
+		tampered := []string{Bip39Dictionary[idx]}
+
+		assert.NotPanics(t, func() {
+			_, err = d.Decode(tampered)
+		})
+		assert.ErrorIs(t, err, ErrInvalidTail)
+	}
+
+	// tail length 15 does not fit 11 bits word
+	_, err = d.Decode([]string{Bip39Dictionary[0b00000001111], "among", "way"})
+	assert.ErrorIs(t, err, ErrInvalidTail)
+}
+
+func TestDic_ChecksumBits(t *testing.T) {
+	tests := []struct {
+		words int
+		want  int
+	}{
+		{2, 1},
+		{4, 1},
+		{32, 2},
+		{256, 5},
+		{2048, 7},
+		{65536, 12},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.words), func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(sequentialWords(tt.words)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d.ChecksumBits())
+
+			assert.Equal(t, d.bitsBatchSize, d.ChecksumBits()+d.tailChecksumLen)
+		})
+	}
+}
+
+func TestNewDictionary_TailFits(t *testing.T) {
+	for bits := 1; bits <= 16; bits++ {
+		d, err := asDictionary(NewDictionary(sequentialWords(1 << bits)))
+		assert.NoError(t, err)
+
+		assert.Equal(t, bits-1, d.MaxTailLen())
+		assert.Less(t, d.MaxTailLen(), 1<<d.tailChecksumLen, "bits %d", bits)
+		assert.Less(t, d.MaxTailLen(), 1<<(bits-d.ChecksumBits()), "bits %d", bits)
+
+		// max tail length round trip
+		mnemonic, err := d.EncodeBits([]byte{255, 255}, d.MaxTailLen())
+		assert.NoError(t, err)
+
+		res, err := d.DecodeDetailed(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, bits-1, res.BitLength)
+		if bits > 1 {
+			assert.Equal(t, bits-1, res.TailLen)
+		}
+	}
+}
+
+func TestDic_IsPrefixFree(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		opts  []Option
+		want  bool
+	}{
+		{"bip39", Bip39Dictionary, nil, false},
+		{"fruits", fruits, nil, true},
+		{"binary", []string{"0", "1"}, nil, true},
+		{"hex", HexDictionary, nil, true},
+		{"prefix", []string{"foo", "bar", "fo", "buzz"}, nil, false},
+		{"prefix with a word between", []string{"a", "ab", "aba", "b"}, nil, false},
+		{"same start", []string{"foo", "fob", "fizz", "fuzz"}, nil, true},
+		{"case sensitive", []string{"A", "ab", "c", "d"}, nil, true},
+		{"case insensitive", []string{"A", "ab", "c", "d"}, []Option{WithCaseInsensitive()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(tt.words, tt.opts...))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d.IsPrefixFree())
+		})
+	}
+}
+
+func TestDic_Decode_InvalidChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic := []string{"fire", "among", "way", "lemon", "extra", "actor", "betray"}
+
+	got, err := d.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	assert.Nil(t, got)
+
+	_, err = d.DecodeInto(mnemonic, make([]byte, 8))
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	res, err := d.DecodeDetailed(mnemonic)
+	assert.NoError(t, err)
+	assert.False(t, res.ChecksumValid)
+	assert.Equal(t, []byte{7, 255, 1, 255, 40, 128, 42, 42}, res.Data)
+}
+
+func TestDic_InspectFirstWord(t *testing.T) {
+	tests := []struct {
+		name         string
+		words        []string
+		word         string
+		wantChecksum string
+		wantTailLen  int
+		wantErr      bool
+	}{
+		// 0101010 1001
+		{"bip39", Bip39Dictionary, "festival", "0101010", 9, false},
+		{"bip39 full tail", Bip39Dictionary, "rose", "1011110", 0, false},
+		{"bip39 invalid tail", Bip39Dictionary, Bip39Dictionary[0b00000001111], "", 0, true},
+		{"unknown word", Bip39Dictionary, "WTF", "", 0, true},
+		{"binary", []string{"0", "1"}, "1", "1", 0, false},
+		{"4 words", []string{"foo", "bar", "fizz", "buzz"}, "fizz", "1", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(tt.words))
+			assert.NoError(t, err)
+
+			checksum, tailLen, err := d.InspectFirstWord(tt.word)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChecksum, checksum)
+			assert.Equal(t, tt.wantTailLen, tailLen)
+		})
+	}
+}
+
+func TestDic_Decode_Large(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, l := range []int{decodeHashChunk - 1, decodeHashChunk, decodeHashChunk + 1, 3*decodeHashChunk + 7} {
+		data := randomBytes(t, l)
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		// corrupt a word in the middle of the payload
+		i := len(mnemonic) / 2
+		mnemonic[i] = d.Words()[(slices.Index(d.Words(), mnemonic[i])+1)%len(d.Words())]
+		res, err := d.DecodeDetailed(mnemonic)
+		assert.NoError(t, err)
+		assert.NotEqual(t, data, res.Data)
+	}
+}
+
+func TestDic_Decode_Allocs(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode(randomBytes(t, 1<<16))
+	assert.NoError(t, err)
+
+	// only the result and the hash state are allocated
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _ = d.Decode(mnemonic)
+	})
+	assert.LessOrEqual(t, allocs, 8.0)
+}
+
+func TestNewDictionaryTopN(t *testing.T) {
+	words := sequentialWords(3000)
+
+	d, err := asDictionary(NewDictionaryTopN(words, 11))
+	assert.NoError(t, err)
+	assert.Equal(t, words[:2048], d.Words())
+
+	d, err = asDictionary(NewDictionaryTopN(words, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"w0", "w1"}, d.Words())
+
+	_, err = NewDictionaryTopN(words, 12)
+	assert.EqualError(t, err, "need at least 4096 words for 12 bits per word, got 3000")
+
+	_, err = NewDictionaryTopN(words, 0)
+	assert.Error(t, err)
+	_, err = NewDictionaryTopN(words, 100)
+	assert.Error(t, err)
+
+	// duplicates only within the top words
+	words[2047] = "w0"
+	_, err = NewDictionaryTopN(words, 11)
+	assert.Error(t, err)
+	words[2047], words[2048] = "w2047", "w0"
+	_, err = NewDictionaryTopN(words, 11)
+	assert.NoError(t, err)
+}
+
+func TestDic_Checksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for l := 0; l < 40; l++ {
+		data := randomBytes(t, l)
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		word, err := d.Checksum(data)
+		assert.NoError(t, err)
+		assert.Equal(t, mnemonic[0], word)
+	}
+
+	// reference implementation of the checksum word
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	words := sha256.Sum256([]byte(strings.Join(Bip39Dictionary, "")))
+	sum := sha256.Sum256(append(slices.Clone(data), words[:]...))
+	checksum := int(sum[0]) >> 1
+	tailLen := len(data) * 8 % 11
+
+	word, err := d.Checksum(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "festival", word)
+	assert.Equal(t, Bip39Dictionary[checksum<<4|tailLen], word)
+	assert.Equal(t, hex.EncodeToString(words[:]), d.Fingerprint())
+}
+
+func TestChecksumEqual(t *testing.T) {
+	assert.True(t, checksumEqual("", ""))
+	assert.True(t, checksumEqual("1010011", "1010011"))
+	assert.False(t, checksumEqual("1010011", "1010010"))
+	assert.False(t, checksumEqual("0010011", "1010011"))
+	assert.False(t, checksumEqual("101001", "1010011"))
+}
+
+func TestDic_Decode_EveryChecksumBit(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithChecksumWords(2)}} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, opts...))
+		assert.NoError(t, err)
+
+		data := []byte("nice!")
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		// flip every checksum bit, the tail length is kept
+		for i, word := range mnemonic[:d.checksumWords] {
+			idx, ok := d.lookupIdx(word)
+			assert.True(t, ok)
+
+			from, to := 0, d.bitsBatchSize
+			if i == 0 {
+				from, to = d.tailChecksumLen, d.tailChecksumLen+d.checksumLen
+			}
+
+			for bit := from; bit < to; bit++ {
+				tampered := slices.Clone(mnemonic)
+				tampered[i] = d.words[idx^1<<bit]
+
+				_, err := d.Decode(tampered)
+				assert.ErrorIs(t, err, ErrInvalidChecksum)
+			}
+		}
+	}
+}
+
+func TestDic_WordBoundary(t *testing.T) {
+	for bitsPerWord := 1; bitsPerWord <= 16; bitsPerWord++ {
+		d, err := asDictionary(NewDictionary(sequentialWords(1 << bitsPerWord)))
+		assert.NoError(t, err)
+
+		// bitsPerWord bytes are always a whole number of words
+		for _, l := range []int{bitsPerWord, 2 * bitsPerWord} {
+			data := randomBytes(t, l)
+			mnemonic, err := d.Encode(data)
+			assert.NoError(t, err)
+			assert.Len(t, mnemonic, 1+l*8/bitsPerWord, "%d bits per word, %d bytes", bitsPerWord, l)
+
+			res, err := d.DecodeDetailed(mnemonic)
+			assert.NoError(t, err)
+			assert.True(t, res.ChecksumValid)
+			assert.Equal(t, data, res.Data)
+			assert.Equal(t, 0, res.TailLen)
+			assert.Equal(t, 0, res.LastWordPaddingBits)
+
+			// the same bits with one more or one less bit
+			// land in the middle of a word
+			for _, bitLen := range []int{l*8 - 1, l*8 + 1} {
+				padded := append(slices.Clone(data), 0)
+				bitsMnemonic, err := d.EncodeBits(padded, bitLen)
+				assert.NoError(t, err)
+				assert.Len(t, bitsMnemonic, 1+(bitLen+bitsPerWord-1)/bitsPerWord)
+
+				_, decodedLen, err := d.DecodeBits(bitsMnemonic)
+				assert.NoError(t, err)
+				assert.Equal(t, bitLen, decodedLen)
+			}
+		}
+	}
+}
+
+// asDictionary asserts the Recoder of a constructor to *Dictionary,
+// as users do to reach all the methods
+func asDictionary(rec Recoder, err error) (*Dictionary, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.(*Dictionary), nil
+}
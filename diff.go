@@ -0,0 +1,93 @@
+package recode
+
+import (
+	"cmp"
+	"slices"
+)
+
+// maxSuggestDistance is the max edit distance of words returned by Suggest
+const maxSuggestDistance = 2
+
+// Diff returns positions where mnemonics a and b differ.
+// If lengths do not match, all the positions after the shorter one differ.
+// Words are compared exactly, e.g. to find a typo in a written down
+// mnemonic comparing it with a freshly generated one.
+func Diff(a, b []string) []int {
+	diff := []int{}
+	for i := range max(len(a), len(b)) {
+		if i >= len(a) || i >= len(b) || a[i] != b[i] {
+			diff = append(diff, i)
+		}
+	}
+
+	return diff
+}
+
+// Suggest returns up to n dictionary words closest to word, none for n <= 0,
+// ordered by edit distance and then by index in the dictionary.
+// Words further than 2 edits are not suggested.
+// If word is in the dictionary, only it is returned.
+// Words are normalized as for Decode.
+func (d *Dictionary) Suggest(word string, n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	if idx, ok := d.lookupIdx(word); ok {
+		return []string{d.words[idx]}
+	}
+
+	type candidate struct {
+		idx      int
+		distance int
+	}
+
+	key := []rune(d.config.normalize(word))
+	candidates := []candidate{}
+	for i, w := range d.words {
+		if distance := editDistance(key, []rune(d.config.normalize(w))); distance <= maxSuggestDistance {
+			candidates = append(candidates, candidate{idx: i, distance: distance})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	suggestions := []string{}
+	for _, c := range candidates[:min(n, len(candidates))] {
+		suggestions = append(suggestions, d.words[c.idx])
+	}
+
+	return suggestions
+}
+
+// editDistance returns optimal string alignment distance between a and b,
+// it is Levenshtein distance where swapping two adjacent letters is one edit,
+// the most common typo in a written down mnemonic
+func editDistance(a, b []rune) int {
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(b)]
+}
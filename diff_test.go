@@ -0,0 +1,69 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []int
+	}{
+		{"equal", []string{"festival", "among", "way"}, []string{"festival", "among", "way"}, []int{}},
+		{"empty", nil, []string{}, []int{}},
+		{"single", []string{"festival", "among", "way"}, []string{"festival", "amount", "way"}, []int{1}},
+		{"several", []string{"festival", "among", "way"}, []string{"fire", "among", "wax"}, []int{0, 2}},
+		{"shorter", []string{"festival", "among"}, []string{"festival", "among", "way", "lemon"}, []int{2, 3}},
+		{"longer", []string{"festival", "among", "way"}, []string{"festival"}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Diff(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDic_Suggest(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"festival"}, d.Suggest("festival", 3))
+	assert.Equal(t, []string{"festival"}, d.Suggest("festivla", 3))
+	assert.Equal(t, []string{"among", "amount", "around"}, d.Suggest("amoung", 3))
+	assert.Equal(t, []string{"among"}, d.Suggest("amoung", 1))
+	assert.Equal(t, []string{}, d.Suggest("xylophone", 3))
+	assert.Equal(t, []string{}, d.Suggest("amoung", 0))
+	assert.Equal(t, []string{}, d.Suggest("amoung", -1))
+	assert.Equal(t, []string{}, d.Suggest("festival", -1))
+
+	ci, err := asDictionary(NewDictionary(Bip39Dictionary, WithCaseInsensitive()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival"}, ci.Suggest("FESTIVLA", 1))
+}
+
+func TestDiff_Suggest(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	generated, err := d.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	written := []string{"festival", "among", "way", "lemon", "extar", "actor", "betray"}
+
+	diff := Diff(generated, written)
+	assert.Equal(t, []int{4}, diff)
+	assert.Equal(t, []string{"extra"}, d.Suggest(written[diff[0]], 1))
+}
+
+func Test_editDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance([]rune("way"), []rune("way")))
+	assert.Equal(t, 3, editDistance([]rune(""), []rune("way")))
+	assert.Equal(t, 1, editDistance([]rune("way"), []rune("wax")))
+	assert.Equal(t, 1, editDistance([]rune("extra"), []rune("extar")))
+	assert.Equal(t, 2, editDistance([]rune("extra"), []rune("etxar")))
+	assert.Equal(t, 3, editDistance([]rune("ca"), []rune("abc")))
+	assert.Equal(t, 1, editDistance([]rune("🍇🍈"), []rune("🍇🍉")))
+}
@@ -0,0 +1,33 @@
+package recode
+
+// Encoder encodes data reusing its internal buffers between calls.
+// It is not safe for concurrent use, but it could be kept in sync.Pool.
+type Encoder struct {
+	d        *Dictionary
+	mnemonic []string
+}
+
+// NewEncoder returns an Encoder for the dictionary.
+func (d *Dictionary) NewEncoder() *Encoder {
+	return &Encoder{d: d}
+}
+
+// Encode converts the input byte slice into a mnemonic.
+// The returned slice is owned by the Encoder and it is valid
+// only until the next call of Encode or Reset, copy it to keep.
+func (e *Encoder) Encode(data []byte) ([]string, error) {
+	mnemonic, err := e.d.EncodeAppend(e.mnemonic[:0], data)
+	if err != nil {
+		return nil, err
+	}
+	e.mnemonic = mnemonic
+
+	return e.mnemonic, nil
+}
+
+// Reset drops references to the words of the last mnemonic,
+// keeping the allocated buffers. Call it before putting Encoder into sync.Pool.
+func (e *Encoder) Reset() {
+	clear(e.mnemonic)
+	e.mnemonic = e.mnemonic[:0]
+}
@@ -0,0 +1,107 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	e := d.NewEncoder()
+	for l := 0; l < 64; l++ {
+		data := randomBytes(t, l)
+
+		want, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		got, err := e.Encode(data)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	e.Reset()
+	got, err := e.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+}
+
+func TestEncoder_SelfVerify(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithSelfVerify()))
+	assert.NoError(t, err)
+
+	d.words[0], d.words[1] = d.words[1], d.words[0]
+
+	_, err = d.NewEncoder().Encode([]byte("1"))
+	assert.ErrorIs(t, err, ErrSelfCheckFailed)
+}
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	data := randomBytes(b, 32)
+
+	b.Run("stateless", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := d.Encode(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("encoder", func(b *testing.B) {
+		e := d.NewEncoder()
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := e.Encode(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestDic_EncodeAppend(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	dst := make([]string, 0, 64)
+	dst = append(dst, "header")
+	for l := 0; l < 16; l++ {
+		data := randomBytes(t, l)
+
+		want, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		start := len(dst)
+		got, err := d.EncodeAppend(dst, data)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got[start:])
+		assert.Equal(t, dst, got[:start])
+
+		dst = got
+	}
+	assert.Equal(t, "header", dst[0])
+
+	buf := make([]string, 0, 16)
+	got, err := d.EncodeAppend(buf, []byte("foo"))
+	assert.NoError(t, err)
+	assert.Same(t, &buf[:1][0], &got[0])
+}
+
+func TestDic_EncodeAppend_SelfVerify(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithSelfVerify()))
+	assert.NoError(t, err)
+
+	d.words[0], d.words[1] = d.words[1], d.words[0]
+
+	dst := []string{"header"}
+	got, err := d.EncodeAppend(dst, []byte("1"))
+	assert.ErrorIs(t, err, ErrSelfCheckFailed)
+	assert.Equal(t, dst, got)
+}
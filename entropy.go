@@ -0,0 +1,101 @@
+package recode
+
+import (
+	"crypto/rand"
+	"fmt"
+	mrand "math/rand/v2"
+	"slices"
+)
+
+// Bip39EntropyLengths are entropy lengths in bytes allowed by bip39,
+// 128, 160, 192, 224 and 256 bits. Use it with WithEntropyLengths.
+var Bip39EntropyLengths = []int{16, 20, 24, 28, 32}
+
+// EncodeEntropy encodes raw entropy bytes, e.g. from crypto/rand.
+// It is the same as Encode, but it makes the intent clear:
+// do not pass utf-8 bytes of a mnemonic or a passphrase here.
+// With WithEntropyLengths, other lengths are rejected with EntropyLengthError.
+func (d *Dictionary) EncodeEntropy(entropy []byte) ([]string, error) {
+	if allowed := d.config.entropyLengths; allowed != nil && !slices.Contains(allowed, len(entropy)) {
+		return nil, &EntropyLengthError{Length: len(entropy), Allowed: slices.Clone(allowed)}
+	}
+
+	return d.encodeVerified(entropy)
+}
+
+// EntropyBitsForWordCount returns the entropy size in bits of a mnemonic
+// of words words, e.g. to check the length of a recovery phrase before Decode.
+// Entropy lengths are set by WithEntropyLengths, Bip39EntropyLengths by default.
+// The first word holds the checksum, so for a bip39 size dictionary
+// it is 13, 16, 19, 22 or 25 words, not 12 to 24 as in bip39.
+// Returns an error for other word counts, or if several lengths
+// give the same word count.
+func (d *Dictionary) EntropyBitsForWordCount(words int) (int, error) {
+	allowed := d.config.entropyLengths
+	if allowed == nil {
+		allowed = Bip39EntropyLengths
+	}
+
+	bits := -1
+	for _, length := range allowed {
+		if d.EncodedLen(length) != words {
+			continue
+		}
+		if bits >= 0 && bits != length*8 {
+			return 0, fmt.Errorf("%d words match several entropy lengths", words)
+		}
+
+		bits = length * 8
+	}
+
+	if bits < 0 {
+		return 0, fmt.Errorf("%d words do not match any entropy length of %v bytes", words, allowed)
+	}
+
+	return bits, nil
+}
+
+// EncodeRandom generates n bytes of entropy with crypto/rand
+// and returns its mnemonic and the entropy, e.g. for a new wallet.
+// Entropy length is checked as in EncodeEntropy.
+func (d *Dictionary) EncodeRandom(n int) ([]string, []byte, error) {
+	if n < 0 {
+		return nil, nil, fmt.Errorf("invalid entropy length %d", n)
+	}
+
+	entropy := make([]byte, n)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, nil, err
+	}
+
+	mnemonic, err := d.EncodeEntropy(entropy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mnemonic, entropy, nil
+}
+
+// EncodeRandomSeeded is EncodeRandom with entropy generated deterministically
+// from seed, so the same n and seed always give the same mnemonic.
+// It is useful for tests, demos and reproducing bug reports.
+//
+// It is NOT cryptographically secure, never use it for real secrets.
+func (d *Dictionary) EncodeRandomSeeded(n int, seed int64) ([]string, []byte, error) {
+	if n < 0 {
+		return nil, nil, fmt.Errorf("invalid entropy length %d", n)
+	}
+
+	rnd := mrand.New(mrand.NewPCG(uint64(seed), 0))
+	entropy := make([]byte, n)
+	for i := range entropy {
+		entropy[i] = byte(rnd.Uint32())
+	}
+
+	mnemonic, err := d.EncodeEntropy(entropy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mnemonic, entropy, nil
+}
@@ -0,0 +1,159 @@
+package recode
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeEntropy(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	entropy := randomBytes(t, 13)
+	want, err := d.Encode(entropy)
+	assert.NoError(t, err)
+
+	got, err := d.EncodeEntropy(entropy)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestWithEntropyLengths(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithEntropyLengths(Bip39EntropyLengths...)))
+	assert.NoError(t, err)
+
+	for _, l := range Bip39EntropyLengths {
+		entropy := randomBytes(t, l)
+		mnemonic, err := d.EncodeEntropy(entropy)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+	}
+
+	for _, l := range []int{0, 15, 17, 33, 64} {
+		_, err := d.EncodeEntropy(randomBytes(t, l))
+
+		var lenErr *EntropyLengthError
+		assert.ErrorAs(t, err, &lenErr)
+		assert.Equal(t, l, lenErr.Length)
+		assert.Equal(t, Bip39EntropyLengths, lenErr.Allowed)
+	}
+
+	_, err = d.EncodeEntropy([]byte("festival among way"))
+	assert.EqualError(t, err, "entropy length 18 bytes is not allowed, expected one of [16 20 24 28 32]")
+
+	// Encode is not limited
+	_, err = d.Encode([]byte("festival among way"))
+	assert.NoError(t, err)
+}
+
+func TestDic_EncodeRandom(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, entropy, err := d.EncodeRandom(32)
+	assert.NoError(t, err)
+	assert.Len(t, entropy, 32)
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, entropy, decoded)
+
+	_, other, err := d.EncodeRandom(32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, entropy, other)
+
+	_, _, err = d.EncodeRandom(-1)
+	assert.Error(t, err)
+
+	strict, err := asDictionary(NewDictionary(Bip39Dictionary, WithEntropyLengths(Bip39EntropyLengths...)))
+	assert.NoError(t, err)
+	_, _, err = strict.EncodeRandom(15)
+	assert.IsType(t, &EntropyLengthError{}, err)
+}
+
+func TestDic_EncodeRandomSeeded(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, entropy, err := d.EncodeRandomSeeded(16, 42)
+	assert.NoError(t, err)
+	// pcg output is stable, so seeded mnemonics are the same across go versions
+	assert.Equal(t, "722d0a02231d8a094b68762f3582f4f6", hex.EncodeToString(entropy))
+	assert.Equal(t, "shaft", mnemonic[0])
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, entropy, decoded)
+
+	again, _, err := d.EncodeRandomSeeded(16, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, again)
+
+	other, _, err := d.EncodeRandomSeeded(16, 43)
+	assert.NoError(t, err)
+	assert.NotEqual(t, mnemonic, other)
+}
+
+func TestDic_EntropyBitsForWordCount(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		words   int
+		want    int
+		wantErr bool
+	}{
+		{13, 128, false},
+		{16, 160, false},
+		{19, 192, false},
+		{22, 224, false},
+		{25, 256, false},
+		// bip39 word counts, the checksum word is extra here
+		{12, 0, true},
+		{15, 0, true},
+		{18, 0, true},
+		{21, 0, true},
+		{24, 0, true},
+		{0, 0, true},
+		{14, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := d.EntropyBitsForWordCount(tt.words)
+		if tt.wantErr {
+			assert.Error(t, err, "%d words", tt.words)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%d words", tt.words)
+
+		mnemonic, _, err := d.EncodeRandom(tt.want / 8)
+		assert.NoError(t, err)
+		assert.Len(t, mnemonic, tt.words)
+	}
+}
+
+func TestDic_EntropyBitsForWordCount_Options(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(1), WithEntropyLengths(16, 32)))
+	assert.NoError(t, err)
+
+	bits, err := d.EntropyBitsForWordCount(14)
+	assert.NoError(t, err)
+	assert.Equal(t, 128, bits)
+
+	_, err = d.EntropyBitsForWordCount(17)
+	assert.Error(t, err)
+
+	// 1 and 2 bytes are both one payload word of 16 bits
+	ambiguous, err := asDictionary(NewDictionary(sequentialWords(65536), WithEntropyLengths(1, 2)))
+	assert.NoError(t, err)
+
+	_, err = ambiguous.EntropyBitsForWordCount(2)
+	assert.Error(t, err)
+}
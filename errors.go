@@ -0,0 +1,114 @@
+package recode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrSelfCheckFailed is returned by Encode with WithSelfVerify option,
+	// when the mnemonic does not decode back to the input.
+	ErrSelfCheckFailed = errors.New("self check failed: mnemonic does not decode to input")
+
+	// ErrDecodeTooExpensive is returned by Decode with WithMaxDecodeWork option,
+	// when the mnemonic is too long to be processed.
+	ErrDecodeTooExpensive = errors.New("decode is too expensive")
+
+	// ErrInvalidChecksum is returned by Decode when the checksum does not match.
+	// Use DecodeDetailed to get the data anyway.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+
+	// ErrInvalidTail is returned by Decode when the tail length
+	// in the first word does not fit the mnemonic.
+	ErrInvalidTail = errors.New("invalid tail")
+
+	// ErrNotUnique is returned by EncodeUnique,
+	// when mnemonic without repeated words was not found.
+	ErrNotUnique = errors.New("unable to encode with unique words")
+
+	// ErrNotAligned is returned by Encode with WithWholeBytesOnly option,
+	// when the data length could not be restored from the number of words.
+	ErrNotAligned = errors.New("data is not aligned for whole bytes mode")
+
+	// ErrTrailingWords is returned by Decode with WithStrictLength option,
+	// when the mnemonic has more payload bits than whole bytes,
+	// e.g. a word was accidentally repeated.
+	ErrTrailingWords = errors.New("mnemonic has trailing words")
+
+	// ErrWrongDictionary is returned by Decode instead of an unknown word error,
+	// when more than half of the words are not in the dictionary,
+	// e.g. the mnemonic was created with another word list.
+	// A mnemonic of a differently ordered dictionary with the same words
+	// could not be detected, it fails with ErrInvalidChecksum.
+	ErrWrongDictionary = errors.New("mnemonic is probably from another dictionary")
+
+	// ErrPayloadTooLarge is returned by Encode and Decode,
+	// when the data is longer than MaxPayloadBytes.
+	ErrPayloadTooLarge = errors.New("payload is too large")
+
+	// ErrWrongPrefix is returned by Decode with WithPayloadPrefix option,
+	// when the payload does not start with the prefix.
+	ErrWrongPrefix = errors.New("payload prefix mismatch")
+)
+
+// Duplicate describes a repeated word in the dictionary.
+type Duplicate struct {
+	Word string
+	// First is the index of the first occurrence of the word
+	First int
+	// Second is the index of the repeated occurrence of the word
+	Second int
+}
+
+// DuplicateError is returned by NewDictionary when words are not unique.
+type DuplicateError struct {
+	Duplicates []Duplicate
+}
+
+func (e *DuplicateError) Error() string {
+	dups := make([]string, 0, len(e.Duplicates))
+	for _, d := range e.Duplicates {
+		dups = append(dups, fmt.Sprintf("%q at %d and %d", d.Word, d.First, d.Second))
+	}
+
+	return "dictionary has duplicates: " + strings.Join(dups, ", ")
+}
+
+// VersionError is returned by Decode with WithVersion option,
+// when the mnemonic has an unexpected version.
+type VersionError struct {
+	Version  int
+	Expected int
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("unknown mnemonic version %d, expected %d", e.Version, e.Expected)
+}
+
+// EntropyLengthError is returned by EncodeEntropy with WithEntropyLengths option,
+// when the entropy length is not allowed.
+type EntropyLengthError struct {
+	Length  int
+	Allowed []int
+}
+
+func (e *EntropyLengthError) Error() string {
+	return fmt.Sprintf("entropy length %d bytes is not allowed, expected one of %v", e.Length, e.Allowed)
+}
+
+// BatchError is returned by EncodeBatch and DecodeBatch,
+// when one of the records fails.
+type BatchError struct {
+	// Index of the failed record
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,54 @@
+package recode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DecodeExactBytes decodes mnemonic of byteLen bytes,
+// for storage keeping the data length out of band.
+// Tail length bits of the first word are ignored, byteLen is trusted instead,
+// but the checksum is still verified.
+// Returns an error if byteLen is inconsistent with the number of words.
+// With WithCompression byteLen is the length of the deflated payload.
+func (d *Dictionary) DecodeExactBytes(mnemonic []string, byteLen int) ([]byte, error) {
+	if byteLen < 0 {
+		return nil, fmt.Errorf("invalid byte length %d", byteLen)
+	}
+	if err := checkPayloadLen(byteLen); err != nil {
+		return nil, err
+	}
+	if err := d.checkDecodeWork(mnemonic); err != nil {
+		return nil, err
+	}
+
+	payload, err := d.stripVersion(mnemonic)
+	if err != nil {
+		return nil, d.checkWrongDictionary(mnemonic, err)
+	}
+	if len(payload) == 0 {
+		return nil, errors.New("empty mnemonic")
+	}
+
+	checksumTailBits, ok := d.lookup(payload[0])
+	if !ok {
+		return nil, d.checkWrongDictionary(payload, errors.New("invalid mnemonic words"))
+	}
+
+	bitsLen := byteLen * 8
+	words := (bitsLen + d.bitsBatchSize - 1) / d.bitsBatchSize
+	if len(payload) != d.checksumWords+words {
+		return nil, fmt.Errorf("%d bytes need %d words, got %d", byteLen, d.headerLen()+words, len(mnemonic))
+	}
+
+	res, err := d.decodePayload(payload, make([]byte, byteLen), checksumTailBits[:d.checksumLen], bitsLen, bitsLen%d.bitsBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.ChecksumValid {
+		return nil, ErrInvalidChecksum
+	}
+
+	return d.config.unwrapPayload(res.Data)
+}
@@ -0,0 +1,72 @@
+package recode
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_DecodeExactBytes(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithVersion(1)}, {WithChecksumWords(2)}, {WithWholeBytesOnly()}} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, opts...))
+		assert.NoError(t, err)
+
+		for _, l := range []int{0, 1, 16, 20, 24} {
+			data := randomBytes(t, l)
+			mnemonic, err := d.Encode(data)
+			assert.NoError(t, err)
+
+			got, err := d.DecodeExactBytes(mnemonic, l)
+			assert.NoError(t, err)
+			assert.Equal(t, data, got)
+		}
+	}
+}
+
+func TestDic_DecodeExactBytes_IgnoresTail(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	// keep checksum bits, change tail length bits
+	idx, _ := d.lookupIdx(mnemonic[0])
+	tampered := slices.Clone(mnemonic)
+	tampered[0] = Bip39Dictionary[idx&^0b1111|1]
+
+	_, err = d.Decode(tampered)
+	assert.Error(t, err)
+
+	got, err := d.DecodeExactBytes(tampered, len(data))
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestDic_DecodeExactBytes_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic := []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}
+
+	_, err = d.DecodeExactBytes(mnemonic, 9)
+	assert.EqualError(t, err, "9 bytes need 8 words, got 7")
+
+	_, err = d.DecodeExactBytes(mnemonic, 6)
+	assert.EqualError(t, err, "6 bytes need 6 words, got 7")
+
+	// 7 bytes fit into the same number of words, but the checksum does not match
+	_, err = d.DecodeExactBytes(mnemonic, 7)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	_, err = d.DecodeExactBytes(mnemonic, -1)
+	assert.Error(t, err)
+
+	_, err = d.DecodeExactBytes(nil, 0)
+	assert.Error(t, err)
+
+	_, err = d.DecodeExactBytes([]string{"fire", "among", "way", "lemon", "extra", "actor", "betray"}, 8)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+}
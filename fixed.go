@@ -0,0 +1,93 @@
+package recode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EncodeFixed encodes data into a mnemonic of exactly words words,
+// e.g. for fixed-slot recovery cards.
+// Payload is framed as:
+//
+//	uvarint(len(data)) || data || zero padding
+//
+// and the padding fills all the bits of the payload words,
+// so the mnemonic length does not depend on the data length.
+// Returns an error if the framed data does not fit,
+// or if words could not be filled with whole bytes of WithWholeBytesOnly,
+// or ErrPayloadTooLarge if words are more than MaxPayloadBytes could give.
+func (d *Dictionary) EncodeFixed(data []byte, words int) ([]string, error) {
+	// check before the capacity is calculated, it could overflow
+	if words-d.headerLen() > d.maxPayloadWords() {
+		return nil, ErrPayloadTooLarge
+	}
+
+	capacity := d.fixedCapacity(words)
+	payload := binary.AppendUvarint(nil, uint64(len(data)))
+	if capacity < 0 || (len(payload)+len(data))*8 > capacity {
+		return nil, fmt.Errorf("%d bytes do not fit into %d words", len(data), words)
+	}
+	// WithWholeBytesOnly payload is whole bytes, for some word counts
+	// the last word would have only padding and it is never encoded
+	if capacity <= (words-d.headerLen()-1)*d.bitsBatchSize {
+		return nil, fmt.Errorf("%d words could not be filled with whole bytes", words)
+	}
+
+	buf := make([]byte, (capacity+7)/8)
+	copy(buf[copy(buf, payload):], data)
+
+	mnemonic, err := d.encodeBits(buf, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.config.selfVerify {
+		decoded, err := d.DecodeFixed(mnemonic)
+		if err != nil || !bytes.Equal(decoded, data) {
+			return nil, ErrSelfCheckFailed
+		}
+	}
+
+	return mnemonic, nil
+}
+
+// DecodeFixed decodes mnemonic created by EncodeFixed and strips the padding.
+func (d *Dictionary) DecodeFixed(mnemonic []string) ([]byte, error) {
+	payload, bitLen, err := d.DecodeBits(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	size, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return nil, errors.New("fixed payload: invalid length")
+	}
+	// the length and the data should fit into whole bytes,
+	// the last byte of payload could be partial
+	whole := bitLen / 8
+	if n > whole || size > uint64(whole-n) {
+		return nil, errors.New("fixed payload: length mismatch")
+	}
+
+	data, padding := payload[n:n+int(size)], payload[n+int(size):]
+	for _, b := range padding {
+		if b != 0 {
+			return nil, errors.New("fixed payload: invalid padding")
+		}
+	}
+
+	return data, nil
+}
+
+// fixedCapacity returns how many payload bits fit into words words,
+// negative if there is no room even for the header
+func (d *Dictionary) fixedCapacity(words int) int {
+	capacity := (words - d.headerLen()) * d.bitsBatchSize
+	if d.config.wholeBytes && capacity > 0 {
+		capacity = capacity / 8 * 8
+	}
+
+	return capacity
+}
@@ -0,0 +1,152 @@
+package recode
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeFixed(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, fruits, {"foo", "bar"}, {"foo", "bar", "fizz", "buzz"}} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+
+		for l := 0; l < 20; l++ {
+			data := randomBytes(t, l)
+			wordsCount := d.EncodedLen(l+1) + 3
+
+			mnemonic, err := d.EncodeFixed(data, wordsCount)
+			assert.NoError(t, err)
+			assert.Len(t, mnemonic, wordsCount)
+
+			decoded, err := d.DecodeFixed(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+		}
+	}
+}
+
+func TestDic_EncodeFixed_Fit(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	// 24 words: 23 * 11 = 253 bits, 31 bytes, 1 for the length
+	data := randomBytes(t, 30)
+	mnemonic, err := d.EncodeFixed(data, 24)
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, 24)
+
+	decoded, err := d.DecodeFixed(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = d.EncodeFixed(randomBytes(t, 31), 24)
+	assert.EqualError(t, err, "31 bytes do not fit into 24 words")
+
+	_, err = d.EncodeFixed(nil, 1)
+	assert.Error(t, err)
+	_, err = d.EncodeFixed(nil, 0)
+	assert.Error(t, err)
+	_, err = d.EncodeFixed([]byte{1}, math.MaxInt)
+	assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	_, err = d.EncodeFixed([]byte{1}, d.maxPayloadWords()+2)
+	assert.ErrorIs(t, err, ErrPayloadTooLarge)
+
+	mnemonic, err = d.EncodeFixed(nil, 2)
+	assert.NoError(t, err)
+	decoded, err = d.DecodeFixed(mnemonic)
+	assert.NoError(t, err)
+	assert.Empty(t, decoded)
+}
+
+func TestDic_DecodeFixed_Invalid(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	// length is bigger than the payload
+	mnemonic, err := d.Encode([]byte{5, 1, 2})
+	assert.NoError(t, err)
+	_, err = d.DecodeFixed(mnemonic)
+	assert.EqualError(t, err, "fixed payload: length mismatch")
+
+	// not zero padding
+	mnemonic, err = d.Encode([]byte{1, 1, 2})
+	assert.NoError(t, err)
+	_, err = d.DecodeFixed(mnemonic)
+	assert.EqualError(t, err, "fixed payload: invalid padding")
+}
+
+func TestDic_DecodeFixed_PartialLength(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	// 7 bits, the uvarint length reaches into the partial last byte
+	mnemonic, err := d.EncodeBits([]byte{0x04}, 7)
+	assert.NoError(t, err)
+
+	_, bitLen, err := d.DecodeBits(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, bitLen)
+
+	_, err = d.DecodeFixed(mnemonic)
+	assert.EqualError(t, err, "fixed payload: length mismatch")
+}
+
+func TestDic_DecodeFixed_NoPanic(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	rnd := rand.New(rand.NewPCG(42, 0))
+	for range 20000 {
+		mnemonic := make([]string, 1+rnd.IntN(4))
+		for i := range mnemonic {
+			mnemonic[i] = Bip39Dictionary[rnd.IntN(len(Bip39Dictionary))]
+		}
+
+		assert.NotPanics(t, func() {
+			_, _ = d.DecodeFixed(mnemonic)
+		}, "%v", mnemonic)
+	}
+}
+
+func TestDic_EncodeFixed_Version(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(2), WithSelfVerify()))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.EncodeFixed([]byte("nice!"), 12)
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, 12)
+
+	decoded, err := d.DecodeFixed(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("nice!"), decoded)
+}
+
+func TestDic_EncodeFixed_WholeBytes(t *testing.T) {
+	d, err := asDictionary(NewDictionary(sequentialWords(8), WithWholeBytesOnly()))
+	assert.NoError(t, err)
+
+	// every 8 payload words of 3 bits are 3 whole bytes, only 0, 3 and 6
+	// words over them are filled with whole bytes without a padding word
+	for words := 2; words <= 26; words++ {
+		mnemonic, err := d.EncodeFixed([]byte{7}, words)
+		payloadWords := words - 1
+		if payloadWords < 6 || payloadWords%8 != 0 && payloadWords%8 != 3 && payloadWords%8 != 6 {
+			assert.Error(t, err, words)
+
+			continue
+		}
+
+		assert.NoError(t, err, words)
+		assert.Len(t, mnemonic, words)
+
+		decoded, err := d.DecodeFixed(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{7}, decoded)
+	}
+
+	_, err = d.EncodeFixed([]byte{7}, 8)
+	assert.EqualError(t, err, "8 words could not be filled with whole bytes")
+}
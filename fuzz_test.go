@@ -0,0 +1,48 @@
+package recode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte("nice!"), uint8(1))
+	f.Add([]byte{}, uint8(5))
+	f.Add([]byte{7, 255, 1, 255, 40, 128, 42, 42}, uint8(11))
+
+	f.Fuzz(func(t *testing.T, data []byte, wordsExp uint8) {
+		d, err := NewDictionary(sequentialWords(1 << (wordsExp%12 + 1)))
+		assert.NoError(t, err)
+
+		encoded, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add("festival among way lemon extra actor betray", uint8(11))
+	f.Add("fizz", uint8(2))
+	f.Add("w1 w2 w3", uint8(1))
+	f.Add("w15", uint8(4))
+
+	bip, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(f, err)
+
+	f.Fuzz(func(t *testing.T, phrase string, wordsExp uint8) {
+		d, err := NewDictionary(sequentialWords(1 << (wordsExp%12 + 1)))
+		assert.NoError(t, err)
+
+		mnemonic := strings.Fields(phrase)
+
+		assert.NotPanics(t, func() {
+			_, _ = d.Decode(mnemonic)
+			_, _ = bip.Decode(mnemonic)
+		})
+	})
+}
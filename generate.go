@@ -0,0 +1,47 @@
+package recode
+
+import "math/rand/v2"
+
+// GenerateDictionary creates a Recoder of size random unique words.
+// The words are generated deterministically from seed, so the same size and seed
+// always give the same dictionary. It is useful for tests, fuzzing, demos
+// and reproducing bug reports.
+//
+// It is NOT cryptographically secure, do not use it to generate
+// dictionaries for real secrets.
+func GenerateDictionary(size int, seed int64) (Recoder, error) {
+	bits, err := BitsPerWord(size)
+	if err != nil {
+		return nil, err
+	}
+	// check before generating words, the size could be huge
+	if err := newConfig(nil).checkMaxBits(bits); err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewPCG(uint64(seed), 0))
+
+	words := make([]string, 0, size)
+	seen := make(map[string]bool, size)
+	for len(words) < size {
+		word := generateWord(rnd)
+		if seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	return NewDictionary(words)
+}
+
+// generateWord returns a random lowercase ascii word 4-10 letters long.
+func generateWord(rnd *rand.Rand) string {
+	b := make([]byte, 4+rnd.IntN(7))
+	for i := range b {
+		b[i] = 'a' + byte(rnd.IntN(26))
+	}
+
+	return string(b)
+}
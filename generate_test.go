@@ -0,0 +1,51 @@
+package recode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDictionary(t *testing.T) {
+	for bits := 1; bits <= 12; bits++ {
+		d, err := asDictionary(GenerateDictionary(1<<bits, 42))
+		assert.NoError(t, err)
+		assert.Len(t, d.Words(), 1<<bits)
+
+		data := randomBytes(t, 33)
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+}
+
+func TestGenerateDictionary_Deterministic(t *testing.T) {
+	a, err := asDictionary(GenerateDictionary(2048, 7))
+	assert.NoError(t, err)
+
+	b, err := asDictionary(GenerateDictionary(2048, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, a.Words(), b.Words())
+
+	c, err := asDictionary(GenerateDictionary(2048, 8))
+	assert.NoError(t, err)
+	assert.NotEqual(t, a.Words(), c.Words())
+}
+
+func TestGenerateDictionary_Error(t *testing.T) {
+	for _, size := range []int{-1, 0, 1, 3, 1000} {
+		_, err := GenerateDictionary(size, 1)
+		assert.Error(t, err, size)
+	}
+
+	// rejected before any words are generated
+	_, err := GenerateDictionary(1<<20, 1)
+	assert.EqualError(t, err, "dictionary of 2^20 words is too large, at most 2^16 words are allowed")
+
+	_, err = GenerateDictionary(math.MaxInt/2+1, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,9 @@
+package recode
+
+var HexDictionary = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "a", "b", "c", "d", "e", "f"}
+
+// NewHexDictionary creates a Recoder with HexDictionary.
+// Useful for debugging, as every word is one hex digit.
+func NewHexDictionary(opts ...Option) (Recoder, error) {
+	return NewDictionary(HexDictionary, opts...)
+}
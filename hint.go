@@ -0,0 +1,36 @@
+package recode
+
+import "encoding/json"
+
+// HintedMnemonic is a mnemonic bundled with a recovery hint.
+// The hint is not encoded into the words and it is not authenticated
+// by the checksum, so never put any secrets into it.
+type HintedMnemonic struct {
+	Mnemonic []string `json:"mnemonic"`
+	Hint     string   `json:"hint,omitempty"`
+}
+
+// Serialize returns JSON representation of the bundle.
+func (m HintedMnemonic) Serialize() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Deserialize restores the bundle from JSON created by Serialize.
+func (m *HintedMnemonic) Deserialize(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+// EncodeWithHint encodes data and bundles the mnemonic with the hint.
+func (d *Dictionary) EncodeWithHint(data []byte, hint string) (HintedMnemonic, error) {
+	mnemonic, err := d.encodeVerified(data)
+	if err != nil {
+		return HintedMnemonic{}, err
+	}
+
+	return HintedMnemonic{Mnemonic: mnemonic, Hint: hint}, nil
+}
+
+// DecodeWithHint decodes mnemonic from the bundle, hint is ignored.
+func (d *Dictionary) DecodeWithHint(m HintedMnemonic) ([]byte, error) {
+	return d.decode(m.Mnemonic)
+}
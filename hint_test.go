@@ -0,0 +1,45 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeWithHint(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+
+	hinted, err := d.EncodeWithHint(data, "the old one from the drawer")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, hinted.Mnemonic)
+	assert.Equal(t, "the old one from the drawer", hinted.Hint)
+
+	serialized, err := hinted.Serialize()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"mnemonic":["festival","among","way","lemon","extra","actor","betray"],"hint":"the old one from the drawer"}`, string(serialized))
+
+	var restored HintedMnemonic
+	assert.NoError(t, restored.Deserialize(serialized))
+	assert.Equal(t, hinted, restored)
+
+	decoded, err := d.DecodeWithHint(restored)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	// hint is not a part of the payload
+	restored.Hint = "something else"
+	decoded, err = d.DecodeWithHint(restored)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestHintedMnemonic_Deserialize(t *testing.T) {
+	var m HintedMnemonic
+	assert.Error(t, m.Deserialize([]byte("not json")))
+
+	assert.NoError(t, m.Deserialize([]byte(`{"mnemonic":["kit"]}`)))
+	assert.Equal(t, HintedMnemonic{Mnemonic: []string{"kit"}}, m)
+}
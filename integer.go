@@ -0,0 +1,47 @@
+package recode
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// EncodeUint64 encodes v in its minimal big-endian form,
+// without leading zero bits, so small values give short mnemonics.
+// With LSBFirst it is little-endian, the low bits go first.
+// The exact bit length is kept as in EncodeBits, 0 is encoded as no bits.
+func (d *Dictionary) EncodeUint64(v uint64) ([]string, error) {
+	bitLen := bits.Len64(v)
+
+	var buf [8]byte
+	if d.config.bitOrder == LSBFirst {
+		// the first bits are the low ones
+		binary.LittleEndian.PutUint64(buf[:], v)
+	} else {
+		// align the value to the left, EncodeBits takes the first bits
+		binary.BigEndian.PutUint64(buf[:], v<<(64-bitLen))
+	}
+
+	return d.EncodeBits(buf[:], bitLen)
+}
+
+// DecodeUint64 decodes mnemonic created by EncodeUint64.
+// Returns an error if the value does not fit into 64 bits.
+func (d *Dictionary) DecodeUint64(mnemonic []string) (uint64, error) {
+	data, bitLen, err := d.DecodeBits(mnemonic)
+	if err != nil {
+		return 0, err
+	}
+
+	if bitLen > 64 {
+		return 0, fmt.Errorf("%d bits do not fit into uint64", bitLen)
+	}
+
+	var buf [8]byte
+	copy(buf[:], data)
+	if d.config.bitOrder == LSBFirst {
+		return binary.LittleEndian.Uint64(buf[:]), nil
+	}
+
+	return binary.BigEndian.Uint64(buf[:]) >> (64 - bitLen), nil
+}
@@ -0,0 +1,53 @@
+package recode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeUint64(t *testing.T) {
+	for _, words := range [][]string{BinaryDictionary, HexDictionary, fruits, Bip39Dictionary} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+
+		for _, v := range []uint64{0, 1, 2, 255, 256, 1 << 32, math.MaxUint64 - 1, math.MaxUint64} {
+			mnemonic, err := d.EncodeUint64(v)
+			assert.NoError(t, err)
+
+			got, err := d.DecodeUint64(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, v, got)
+		}
+	}
+}
+
+func TestDic_EncodeUint64_Minimal(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	zero, err := d.EncodeUint64(0)
+	assert.NoError(t, err)
+	assert.Len(t, zero, 1)
+
+	// 11 bits is one word
+	small, err := d.EncodeUint64(2047)
+	assert.NoError(t, err)
+	assert.Len(t, small, 2)
+
+	large, err := d.EncodeUint64(math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Len(t, large, 7)
+}
+
+func TestDic_DecodeUint64_TooLong(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode(randomBytes(t, 9))
+	assert.NoError(t, err)
+
+	_, err = d.DecodeUint64(mnemonic)
+	assert.EqualError(t, err, "72 bits do not fit into uint64")
+}
@@ -0,0 +1,111 @@
+package recode
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// maxBitsPerWord is the biggest supported dictionary, 65536 words
+const maxBitsPerWord = 16
+
+// MaxPayloadBytes is the biggest data Encode accepts and Decode returns.
+// Payload bit counts are int, so with the word rounding they should
+// fit into 2^31 on 32-bit platforms, which limits data to 128MiB there.
+// On 64-bit platforms it is practically unlimited.
+const MaxPayloadBytes = math.MaxInt / 16
+
+// checkPayloadLen checks that dataLen bytes fit into MaxPayloadBytes,
+// before their bit length is calculated
+func checkPayloadLen(dataLen int) error {
+	if dataLen > MaxPayloadBytes {
+		return ErrPayloadTooLarge
+	}
+
+	return nil
+}
+
+// maxPayloadWords returns how many payload words MaxPayloadBytes take
+func (d *Dictionary) maxPayloadWords() int {
+	return (MaxPayloadBytes*8 + d.bitsBatchSize - 1) / d.bitsBatchSize
+}
+
+// BitsPerWord returns how many bits every word of a wordCount words dictionary encodes,
+// it is log2(wordCount). Returns an error if wordCount is not a power of two,
+// the same as NewDictionary does, so a word list could be checked
+// before building the dictionary.
+func BitsPerWord(wordCount int) (int, error) {
+	if wordCount < 2 || (wordCount&(wordCount-1)) != 0 {
+		return 0, errors.New("dictionary should be complete and len(words) == 2^N")
+	}
+
+	return bits.TrailingZeros(uint(wordCount)), nil
+}
+
+// WordsForData returns how many words Encode yields for dataBits bits of data
+// with a dictionary of 2^bitsPerWord words: one checksum word and
+// the data split into words, the last one is padded.
+// Version word, see WithVersion, is not included.
+// Returns 0 if bitsPerWord < 1 or dataBits < 0.
+func WordsForData(dataBits, bitsPerWord int) int {
+	if bitsPerWord < 1 || dataBits < 0 {
+		return 0
+	}
+
+	return 1 + (dataBits+bitsPerWord-1)/bitsPerWord
+}
+
+// RecommendedBitsPerWord returns the smallest bits per word,
+// so dataBits bits of data fit into targetWords words, e.g. 128 bits
+// fit into 13 words with 11 bits per word (2048 words dictionary).
+// Returns 0 if it is impossible even with the biggest dictionary.
+func RecommendedBitsPerWord(dataBits, targetWords int) int {
+	for bits := 1; bits <= maxBitsPerWord; bits++ {
+		if words := WordsForData(dataBits, bits); words > 0 && words <= targetWords {
+			return bits
+		}
+	}
+
+	return 0
+}
+
+// EncodedLen returns how many words Encode yields for dataLen bytes of data,
+// 0 for data longer than MaxPayloadBytes,
+// including the version word and extra checksum words if any,
+// and the prefix of WithPayloadPrefix.
+// With WithCompression it is an upper bound, the size of incompressible data.
+func (d *Dictionary) EncodedLen(dataLen int) int {
+	if checkPayloadLen(dataLen) != nil {
+		return 0
+	}
+
+	payloadLen := d.config.maxPayloadLen(dataLen)
+	if checkPayloadLen(payloadLen) != nil {
+		return 0
+	}
+
+	return WordsForData(payloadLen*8, d.bitsBatchSize) - 1 + d.headerLen()
+}
+
+// EncodedByteSize returns the utf-8 byte length of the mnemonic joined as
+// EncodeString does, with spaces or the separator set by WithSeparator,
+// e.g. to fit it into a QR code or an NFC tag.
+// Emoji words are 4 or more bytes long, so the size could be
+// much larger than the number of words suggests, see EncodedLen.
+func (d *Dictionary) EncodedByteSize(mnemonic []string) int {
+	if len(mnemonic) == 0 {
+		return 0
+	}
+
+	sepLen := 1
+	if d.config.separator != "" {
+		sepLen = len(d.config.separator)
+	}
+
+	size := (len(mnemonic) - 1) * sepLen
+	for _, word := range mnemonic {
+		size += len(word)
+	}
+
+	return size
+}
@@ -0,0 +1,188 @@
+package recode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordsForData(t *testing.T) {
+	assert.Equal(t, 13, WordsForData(128, 11))
+	assert.Equal(t, 25, WordsForData(256, 11))
+	assert.Equal(t, 1, WordsForData(0, 11))
+	assert.Equal(t, 2, WordsForData(1, 11))
+	assert.Equal(t, 0, WordsForData(8, 0))
+	assert.Equal(t, 0, WordsForData(-1, 8))
+
+	for bits := 1; bits <= maxBitsPerWord; bits++ {
+		d, err := asDictionary(GenerateDictionary(1<<bits, 1))
+		assert.NoError(t, err)
+
+		for l := 0; l < 40; l++ {
+			mnemonic, err := d.Encode(randomBytes(t, l))
+			assert.NoError(t, err)
+			assert.Len(t, mnemonic, WordsForData(l*8, bits), "bits %d, len %d", bits, l)
+			assert.Len(t, mnemonic, d.EncodedLen(l))
+		}
+	}
+}
+
+func TestRecommendedBitsPerWord(t *testing.T) {
+	assert.Equal(t, 11, RecommendedBitsPerWord(128, 13))
+	assert.Equal(t, 12, RecommendedBitsPerWord(128, 12))
+	assert.Equal(t, 13, RecommendedBitsPerWord(128, 11))
+	assert.Equal(t, 1, RecommendedBitsPerWord(8, 100))
+	assert.Equal(t, 0, RecommendedBitsPerWord(128, 1))
+}
+
+func TestDic_EncodedLen_Version(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(3)))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode(randomBytes(t, 16))
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, d.EncodedLen(16))
+	assert.Equal(t, 14, d.EncodedLen(16))
+}
+
+func TestDic_EncodedLen_Wrapped(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte("app1"))))
+	assert.NoError(t, err)
+
+	for _, n := range []int{0, 1, 16, 100} {
+		mnemonic, err := d.Encode(randomBytes(t, n))
+		assert.NoError(t, err)
+		assert.Len(t, mnemonic, d.EncodedLen(n))
+	}
+
+	d, err = asDictionary(NewDictionary(Bip39Dictionary, WithCompression(), WithPayloadPrefix([]byte("app1"))))
+	assert.NoError(t, err)
+
+	for _, n := range []int{0, 1, 16, 100, 1000} {
+		// random data is stored raw, the size is exact
+		mnemonic, err := d.Encode(randomBytes(t, n))
+		assert.NoError(t, err)
+		assert.Len(t, mnemonic, d.EncodedLen(n))
+
+		mnemonic, err = d.Encode(make([]byte, n))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(mnemonic), d.EncodedLen(n))
+	}
+}
+
+func TestBitsPerWord(t *testing.T) {
+	tests := []struct {
+		wordCount int
+		want      int
+		wantErr   bool
+	}{
+		{2, 1, false},
+		{32, 5, false},
+		{2048, 11, false},
+		{65536, 16, false},
+		{1000, 0, true},
+		{1, 0, true},
+		{0, 0, true},
+		{-2, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := BitsPerWord(tt.wordCount)
+		if tt.wantErr {
+			assert.Error(t, err, tt.wordCount)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.wordCount)
+	}
+}
+
+func TestDic_EncodedByteSize(t *testing.T) {
+	for _, tt := range []struct {
+		words []string
+		opts  []Option
+	}{
+		{Bip39Dictionary, nil},
+		{fruits, nil},
+		{[]string{"ice cream", "apple pie", "hot dog", "fish and chips"}, []Option{WithSeparator(" | ")}},
+	} {
+		d, err := asDictionary(NewDictionary(tt.words, tt.opts...))
+		assert.NoError(t, err)
+
+		for l := 0; l < 40; l++ {
+			data := randomBytes(t, l)
+			mnemonic, err := d.Encode(data)
+			assert.NoError(t, err)
+
+			phrase, err := d.EncodeString(data)
+			assert.NoError(t, err)
+			assert.Equal(t, len(phrase), d.EncodedByteSize(mnemonic))
+		}
+	}
+
+	d, err := asDictionary(NewDictionary(fruits))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, d.EncodedByteSize(nil))
+	// 🌶️ is a pepper with a variation selector
+	assert.Equal(t, 4+1+7, d.EncodedByteSize([]string{"🍇", "🌶️"}))
+}
+
+func TestDic_EntropyBits(t *testing.T) {
+	d, err := asDictionary(NewDictionary(fruits))
+	assert.NoError(t, err)
+
+	// fruits wallet 128 bit vector
+	mnemonic := strings.Fields("🍒 🥥 🍒 🥜 🍐 🍐 🍈 🍌 🥥 🫐 🍉 🍒 🫒 🫘 🍅 🧄 🧅 🥥 🍆 🍈 🥒 🍎 🫒 🍉 🥥 🥝 🍆")
+	bits, err := d.EntropyBits(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, 128, bits)
+
+	bip39, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(1)))
+	assert.NoError(t, err)
+	for _, l := range []int{0, 1, 16, 32} {
+		mnemonic, err := bip39.Encode(randomBytes(t, l))
+		assert.NoError(t, err)
+
+		bits, err := bip39.EntropyBits(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, l*8, bits)
+	}
+
+	bitsMnemonic, err := bip39.EncodeBits([]byte{0xff}, 5)
+	assert.NoError(t, err)
+	bits, err = bip39.EntropyBits(bitsMnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, bits)
+
+	_, err = d.EntropyBits(nil)
+	assert.Error(t, err)
+}
+
+func TestMaxPayloadBytes(t *testing.T) {
+	assert.NoError(t, checkPayloadLen(MaxPayloadBytes))
+	assert.ErrorIs(t, checkPayloadLen(MaxPayloadBytes+1), ErrPayloadTooLarge)
+
+	// bit counts of the biggest payload do not overflow int
+	assert.Greater(t, MaxPayloadBytes*8+maxBitsPerWord, 0)
+
+	for bits := 1; bits <= maxBitsPerWord; bits++ {
+		d, err := asDictionary(NewDictionary(sequentialWords(1 << bits)))
+		assert.NoError(t, err)
+
+		maxWords := d.maxPayloadWords()
+		bitsLen, err := d.payloadBits(maxWords, 0)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, bitsLen/8, MaxPayloadBytes, "bits %d", bits)
+
+		_, err = d.payloadBits(maxWords+1, 0)
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+
+		assert.Equal(t, 0, d.EncodedLen(MaxPayloadBytes+1))
+		assert.Positive(t, d.EncodedLen(MaxPayloadBytes))
+
+		_, err = d.DecodeExactBytes([]string{d.Words()[0]}, MaxPayloadBytes+1)
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	}
+}
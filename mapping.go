@@ -0,0 +1,46 @@
+package recode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewDictionaryFromMapping creates a new Recoder with explicit word values.
+// Values should be a complete set 0..2^N-1 without gaps and duplicates.
+// It is useful for external word lists with canonical numbering.
+func NewDictionaryFromMapping(mapping map[string]int, opts ...Option) (Recoder, error) {
+	bitsBatchSize, err := BitsPerWord(len(mapping))
+	if err != nil {
+		return nil, err
+	}
+
+	c := newConfig(opts)
+	if err := c.checkMaxBits(bitsBatchSize); err != nil {
+		return nil, err
+	}
+
+	words := make([]string, len(mapping))
+	for word, idx := range mapping {
+		if idx < 0 || idx >= len(mapping) {
+			return nil, fmt.Errorf("word %q has value %d out of range [0, %d)", word, idx, len(mapping))
+		}
+
+		word = strings.TrimSpace(word)
+		if word == "" {
+			return nil, errors.New("words should not be empty")
+		}
+
+		if words[idx] != "" {
+			return nil, fmt.Errorf("words %q and %q have the same value %d", words[idx], word, idx)
+		}
+		words[idx] = word
+	}
+
+	d, err := buildDictionary(words, bitsBatchSize, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,51 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDictionaryFromMapping(t *testing.T) {
+	mapping := make(map[string]int, len(Bip39Dictionary))
+	for i, word := range Bip39Dictionary {
+		mapping[word] = i
+	}
+
+	d, err := asDictionary(NewDictionaryFromMapping(mapping))
+	assert.NoError(t, err)
+	assert.Equal(t, Bip39Dictionary, d.Words())
+
+	got, err := d.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+	// custom order
+	d, err = asDictionary(NewDictionaryFromMapping(map[string]int{"fizz": 2, "buzz": 3, "foo": 0, "bar": 1}))
+	assert.NoError(t, err)
+
+	got, err = d.Encode([]byte("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fizz", "foo", "buzz", "foo", "bar"}, got)
+}
+
+func TestNewDictionaryFromMapping_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string]int
+	}{
+		{"empty", map[string]int{}},
+		{"not power of two", map[string]int{"foo": 0, "bar": 1, "fizz": 2}},
+		{"gap", map[string]int{"foo": 0, "bar": 1, "fizz": 2, "buzz": 4}},
+		{"negative", map[string]int{"foo": 0, "bar": 1, "fizz": 2, "buzz": -1}},
+		{"duplicate value", map[string]int{"foo": 0, "bar": 1, "fizz": 2, "buzz": 2}},
+		{"empty word", map[string]int{"foo": 0, "bar": 1, "fizz": 2, " ": 3}},
+		{"duplicate after trim", map[string]int{"foo": 0, "bar": 1, "fizz": 2, "foo ": 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDictionaryFromMapping(tt.mapping)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package recode
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+)
+
+// binaryFormatVersion is the first byte of MarshalBinary output
+const binaryFormatVersion byte = 1
+
+// MarshalBinary encodes the dictionary words, so it could be restored
+// with UnmarshalDictionary without validating raw word list.
+// Options are not included.
+func (d *Dictionary) MarshalBinary() ([]byte, error) {
+	size := 2
+	for _, word := range d.words {
+		size += binary.MaxVarintLen64 + len(word)
+	}
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, binaryFormatVersion, byte(d.bitsBatchSize))
+	for _, word := range d.words {
+		buf = binary.AppendUvarint(buf, uint64(len(word)))
+		buf = append(buf, word...)
+	}
+
+	return buf, nil
+}
+
+// unmarshalBinary builds a new dictionary with config c from MarshalBinary output
+func unmarshalBinary(data []byte, c config) (*Dictionary, error) {
+	if len(data) < 2 {
+		return nil, errors.New("binary dictionary: too short")
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, errors.New("binary dictionary: unknown version")
+	}
+
+	bitsBatchSize := int(data[1])
+	if bitsBatchSize < 1 || bitsBatchSize > 16 {
+		return nil, errors.New("binary dictionary: invalid bits size")
+	}
+
+	count := 1 << bitsBatchSize
+	words := make([]string, 0, count)
+	data = data[2:]
+	for len(data) > 0 {
+		l, n := binary.Uvarint(data)
+		if n <= 0 || l == 0 || l > uint64(len(data)-n) {
+			return nil, errors.New("binary dictionary: invalid word")
+		}
+
+		words = append(words, string(data[n:n+int(l)]))
+		data = data[n+int(l):]
+	}
+
+	if len(words) != count {
+		return nil, errors.New("binary dictionary: words count mismatch")
+	}
+
+	return buildDictionary(words, bitsBatchSize, c)
+}
+
+// UnmarshalDictionary creates a new Recoder from MarshalBinary output.
+//
+// Dictionary does not implement encoding.BinaryUnmarshaler on purpose:
+// unmarshaling in place would let any holder of a shared Recoder,
+// e.g. DefaultBip39, replace it under concurrent readers.
+// An existing Recoder is never changed, a new one is returned instead.
+func UnmarshalDictionary(data []byte, opts ...Option) (Recoder, error) {
+	d, err := unmarshalBinary(data, newConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// hashesByName are the hashes supported by MarshalJSON and UnmarshalDictionaryJSON
+var hashesByName = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+	"crc32": func() hash.Hash {
+		return crc32.NewIEEE()
+	},
+}
+
+// jsonDictionary is the JSON form of the dictionary configuration
+type jsonDictionary struct {
+	Words           []string `json:"words"`
+	Hash            string   `json:"hash"`
+	CaseInsensitive bool     `json:"caseInsensitive"`
+}
+
+// MarshalJSON encodes the dictionary words, the hash name and
+// case insensitivity, e.g. to keep a dictionary spec in a config file.
+// Other options are not included.
+// Returns an error for a custom hash set with WithHash,
+// use WithNamedHash instead.
+func (d *Dictionary) MarshalJSON() ([]byte, error) {
+	if d.config.hashName == "" {
+		return nil, errors.New("json dictionary: custom hash could not be marshaled")
+	}
+
+	return json.Marshal(jsonDictionary{
+		Words:           d.words,
+		Hash:            d.config.hashName,
+		CaseInsensitive: d.config.caseInsensitive,
+	})
+}
+
+// UnmarshalDictionaryJSON creates a new Recoder from MarshalJSON output,
+// validating words as NewDictionary does.
+// The hash and case insensitivity from data override opts.
+//
+// Dictionary does not implement json.Unmarshaler on purpose,
+// for the same reason as for UnmarshalDictionary:
+// an existing Recoder is never changed, a new one is returned instead.
+func UnmarshalDictionaryJSON(data []byte, opts ...Option) (Recoder, error) {
+	var spec jsonDictionary
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("json dictionary: %w", err)
+	}
+
+	if _, ok := hashesByName[spec.Hash]; !ok {
+		return nil, fmt.Errorf("json dictionary: unknown hash %q", spec.Hash)
+	}
+
+	opts = append(opts[:len(opts):len(opts)], func(c *config) {
+		c.caseInsensitive = spec.CaseInsensitive
+	}, WithNamedHash(spec.Hash))
+
+	return NewDictionary(spec.Words, opts...)
+}
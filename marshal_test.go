@@ -0,0 +1,156 @@
+package recode
+
+import (
+	"crypto/sha512"
+	"encoding"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_MarshalBinary(t *testing.T) {
+	for _, words := range [][]string{
+		{"0", "1"},
+		Bip39Dictionary,
+		fruits,
+		sequentialWords(65536),
+	} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+
+		data, err := d.MarshalBinary()
+		assert.NoError(t, err)
+
+		restored, err := asDictionary(UnmarshalDictionary(data))
+		assert.NoError(t, err)
+		assert.Equal(t, d.Fingerprint(), restored.Fingerprint())
+		assert.Equal(t, d.Words(), restored.Words())
+
+		mnemonic, err := d.Encode([]byte("nice!"))
+		assert.NoError(t, err)
+
+		decoded, err := restored.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("nice!"), decoded)
+	}
+}
+
+func TestDic_NotUnmarshaler(t *testing.T) {
+	// a shared Dictionary, e.g. DefaultBip39, should not be changed in place
+	var rec any = DefaultBip39()
+
+	_, ok := rec.(encoding.BinaryUnmarshaler)
+	assert.False(t, ok)
+	_, ok = rec.(json.Unmarshaler)
+	assert.False(t, ok)
+}
+
+func TestUnmarshalDictionary_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"unknown version", []byte{42, 1, 1, '0', 1, '1'}},
+		{"invalid bits", []byte{binaryFormatVersion, 0}},
+		{"too many bits", []byte{binaryFormatVersion, 17}},
+		{"missing word", []byte{binaryFormatVersion, 1, 1, '0'}},
+		{"extra word", []byte{binaryFormatVersion, 1, 1, '0', 1, '1', 1, '2'}},
+		{"empty word", []byte{binaryFormatVersion, 1, 1, '0', 0}},
+		{"truncated word", []byte{binaryFormatVersion, 1, 1, '0', 5, '1'}},
+		{"duplicate", []byte{binaryFormatVersion, 1, 1, '0', 1, '0'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalDictionary(tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDic_MarshalJSON(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data, err := d.MarshalJSON()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), `{"words":["abandon","ability",`))
+	assert.True(t, strings.HasSuffix(string(data), `"zoo"],"hash":"sha256","caseInsensitive":false}`))
+
+	restored, err := asDictionary(UnmarshalDictionaryJSON(data))
+	assert.NoError(t, err)
+	assert.Equal(t, d.Fingerprint(), restored.Fingerprint())
+
+	mnemonic, err := d.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	decoded, err := restored.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("nice!"), decoded)
+}
+
+func TestDic_MarshalJSON_Options(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCRC32(), WithCaseInsensitive()))
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"hash":"crc32","caseInsensitive":true`)
+
+	restored, err := UnmarshalDictionaryJSON(data)
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	for i := range mnemonic {
+		mnemonic[i] = strings.ToUpper(mnemonic[i])
+	}
+	decoded, err := restored.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("nice!"), decoded)
+
+	custom, err := asDictionary(NewDictionary(Bip39Dictionary, WithHash(sha512.New)))
+	assert.NoError(t, err)
+	_, err = custom.MarshalJSON()
+	assert.Error(t, err)
+
+	// the README example
+	rec, err := NewDictionary(Bip39Dictionary, WithCaseInsensitive(), WithNamedHash("sha512"))
+	assert.NoError(t, err)
+	data, err = json.Marshal(rec)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"hash":"sha512","caseInsensitive":true`)
+
+	want, err := custom.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+	mnemonic, err = rec.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+	assert.Equal(t, want, mnemonic)
+
+	_, err = NewDictionary(Bip39Dictionary, WithNamedHash("md5"))
+	assert.EqualError(t, err, `unknown hash "md5"`)
+}
+
+func TestUnmarshalDictionaryJSON_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", `{"words":`},
+		{"unknown hash", `{"words":["0","1"],"hash":"md5"}`},
+		{"missing hash", `{"words":["0","1"]}`},
+		{"not power of two", `{"words":["0","1","2"],"hash":"sha256"}`},
+		{"empty word", `{"words":["0"," "],"hash":"sha256"}`},
+		{"duplicate", `{"words":["0","0"],"hash":"sha256"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalDictionaryJSON([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package recode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EncodeMulti encodes several independent chunks into one mnemonic.
+// Every chunk is encoded as a separate record, with its own checksum word,
+// and its payload is prefixed with uvarint length of the payload.
+// So DecodeMulti can find where the record ends, from the first
+// few words of the record.
+// With WithPayloadPrefix and WithCompression every chunk is wrapped
+// on its own, the length prefix stays in front of the wrapped chunk.
+func (d *Dictionary) EncodeMulti(chunks [][]byte) ([]string, error) {
+	mnemonic := []string{}
+	for i, chunk := range chunks {
+		words, err := d.encodeMultiRecord(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", i, err)
+		}
+
+		mnemonic = append(mnemonic, words...)
+	}
+
+	return mnemonic, nil
+}
+
+// DecodeMulti decodes mnemonic created by EncodeMulti.
+func (d *Dictionary) DecodeMulti(mnemonic []string) ([][]byte, error) {
+	chunks := [][]byte{}
+	for len(mnemonic) > 0 {
+		recordLen, err := d.multiRecordLen(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", len(chunks), err)
+		}
+
+		chunk, err := d.decodeMultiRecord(mnemonic[:recordLen])
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", len(chunks), err)
+		}
+
+		chunks = append(chunks, chunk)
+		mnemonic = mnemonic[recordLen:]
+	}
+
+	return chunks, nil
+}
+
+// encodeMultiRecord encodes one chunk of EncodeMulti
+func (d *Dictionary) encodeMultiRecord(chunk []byte) ([]string, error) {
+	wrapped, err := d.config.wrapPayload(chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := binary.AppendUvarint(nil, uint64(len(wrapped)))
+	payload = append(payload, wrapped...)
+
+	mnemonic, err := d.encode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.config.selfVerify {
+		decoded, err := d.decodeMultiRecord(mnemonic)
+		if err != nil || !bytes.Equal(decoded, chunk) {
+			return nil, ErrSelfCheckFailed
+		}
+	}
+
+	return mnemonic, nil
+}
+
+// decodeMultiRecord decodes one record of DecodeMulti,
+// the length prefix is stripped before the payload is unwrapped
+func (d *Dictionary) decodeMultiRecord(record []string) ([]byte, error) {
+	res, err := d.decodeRaw(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.ChecksumValid {
+		return nil, ErrInvalidChecksum
+	}
+
+	// checksum is valid, so is the length prefix
+	_, n := binary.Uvarint(res.Data)
+
+	return d.config.unwrapPayload(res.Data[n:])
+}
+
+// multiRecordLen returns how many words the first record of mnemonic takes
+func (d *Dictionary) multiRecordLen(mnemonic []string) (int, error) {
+	// version and checksum words
+	headerLen := d.headerLen()
+	if len(mnemonic) < headerLen {
+		return 0, errors.New("mnemonic is too short for the chunk")
+	}
+
+	// enough words to read the longest uvarint, the stream decoder
+	// holds back the last word, so one more
+	prefixWords := headerLen + (binary.MaxVarintLen64*8+d.bitsBatchSize-1)/d.bitsBatchSize + 1
+	prefixWords = min(prefixWords, len(mnemonic))
+
+	// bytes are read as Decode does, with the bit order of the dictionary
+	sd := d.newStreamDecoder()
+	for _, word := range mnemonic[:prefixWords] {
+		if err := sd.push(word); err != nil {
+			return 0, err
+		}
+	}
+	if sd.hasPending && prefixWords == len(mnemonic) {
+		// the last word of the mnemonic, its padding could not be a part of the length
+		if err := sd.appendBits(sd.pending, -1); err != nil {
+			return 0, err
+		}
+	}
+
+	l, n := binary.Uvarint(sd.out)
+	if n <= 0 || l > uint64(len(mnemonic)*d.bitsBatchSize/8) {
+		return 0, errors.New("invalid chunk length")
+	}
+
+	payloadBits := (n + int(l)) * 8
+	recordLen := headerLen + (payloadBits+d.bitsBatchSize-1)/d.bitsBatchSize
+	if recordLen > len(mnemonic) {
+		return 0, errors.New("mnemonic is too short for the chunk")
+	}
+
+	return recordLen, nil
+}
@@ -0,0 +1,126 @@
+package recode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeMulti(t *testing.T) {
+	tests := []struct {
+		name   string
+		words  []string
+		chunks [][]byte
+	}{
+		{"bip39", Bip39Dictionary, [][]byte{[]byte("nice!"), {}, {7, 255, 1, 255, 40, 128, 42, 42}}},
+		{"binary", []string{"0", "1"}, [][]byte{{42}, {}, {1, 2, 3}}},
+		{"fruits", fruits, [][]byte{{}, []byte("nice!"), {}}},
+		{"4 words", []string{"foo", "bar", "fizz", "buzz"}, [][]byte{[]byte("1"), {}, []byte("long enough chunk to have two bytes uvarint length prefix, long enough chunk to have two bytes uvarint length prefix")}},
+		{"one chunk", Bip39Dictionary, [][]byte{[]byte("nice!")}},
+		{"no chunks", Bip39Dictionary, [][]byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := asDictionary(NewDictionary(tt.words))
+			assert.NoError(t, err)
+
+			mnemonic, err := d.EncodeMulti(tt.chunks)
+			assert.NoError(t, err)
+
+			got, err := d.DecodeMulti(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.chunks, got)
+		})
+	}
+}
+
+func TestDic_DecodeMulti_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.EncodeMulti([][]byte{[]byte("nice!"), {}, []byte("nice!")})
+	assert.NoError(t, err)
+
+	// truncated
+	_, err = d.DecodeMulti(mnemonic[:len(mnemonic)-1])
+	assert.Error(t, err)
+
+	// invalid word
+	broken := append([]string{}, mnemonic...)
+	broken[2] = "WTF"
+	_, err = d.DecodeMulti(broken)
+	assert.Error(t, err)
+
+	// plain mnemonic
+	plain, err := d.Encode([]byte{255, 255, 255})
+	assert.NoError(t, err)
+	_, err = d.DecodeMulti(plain)
+	assert.Error(t, err)
+}
+
+func TestDic_EncodeMulti_BitOrder(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, {"foo", "bar", "fizz", "buzz"}, fruits} {
+		d, err := asDictionary(NewDictionary(words, WithBitOrder(LSBFirst)))
+		assert.NoError(t, err)
+
+		chunks := [][]byte{[]byte("nice!"), {}, make([]byte, 200)}
+		mnemonic, err := d.EncodeMulti(chunks)
+		assert.NoError(t, err)
+
+		got, err := d.DecodeMulti(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, chunks, got)
+	}
+}
+
+func TestDic_EncodeMulti_PayloadPrefix(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, {"foo", "bar", "fizz", "buzz"}, fruits} {
+		d, err := asDictionary(NewDictionary(words, WithPayloadPrefix([]byte{9, 9}), WithSelfVerify()))
+		assert.NoError(t, err)
+
+		chunks := [][]byte{[]byte("nice!"), {}, make([]byte, 200)}
+		mnemonic, err := d.EncodeMulti(chunks)
+		assert.NoError(t, err)
+
+		got, err := d.DecodeMulti(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, chunks, got)
+	}
+
+	// records of another prefix are rejected
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte{9, 9})))
+	assert.NoError(t, err)
+	other, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte{8, 8})))
+	assert.NoError(t, err)
+
+	mnemonic, err := other.EncodeMulti([][]byte{[]byte("nice!")})
+	assert.NoError(t, err)
+	_, err = d.DecodeMulti(mnemonic)
+	assert.ErrorIs(t, err, ErrWrongPrefix)
+}
+
+func TestDic_EncodeMulti_Compression(t *testing.T) {
+	text := []byte(strings.Repeat("nice! ", 50))
+	for _, opts := range [][]Option{
+		{WithCompression()},
+		{WithCompression(), WithPayloadPrefix([]byte{9, 9})},
+	} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, append(opts, WithSelfVerify())...))
+		assert.NoError(t, err)
+		plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+		assert.NoError(t, err)
+
+		chunks := [][]byte{text, {}, []byte("nice!"), text}
+		mnemonic, err := d.EncodeMulti(chunks)
+		assert.NoError(t, err)
+
+		got, err := d.DecodeMulti(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, chunks, got)
+
+		uncompressed, err := plain.EncodeMulti(chunks)
+		assert.NoError(t, err)
+		assert.Less(t, len(mnemonic), len(uncompressed))
+	}
+}
@@ -0,0 +1,18 @@
+package recode
+
+// DecodeNoChecksum decodes a mnemonic created with WithoutChecksum
+// by a dictionary of the same words and otherwise the same options,
+// e.g. to read old backups after the checksum was enabled.
+// The first word is read only for the tail length,
+// all the other words are payload, nothing is verified.
+func (d *Dictionary) DecodeNoChecksum(mnemonic []string) ([]byte, error) {
+	if d.config.withoutChecksum {
+		return d.decode(mnemonic)
+	}
+
+	noChecksum := *d
+	noChecksum.config.withoutChecksum = true
+	noChecksum.checksumWords = 1
+
+	return noChecksum.Decode(mnemonic)
+}
@@ -0,0 +1,47 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_DecodeNoChecksum(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithVersion(3)}, {WithChecksumWords(2)}} {
+		d, err := asDictionary(NewDictionary(Bip39Dictionary, opts...))
+		assert.NoError(t, err)
+
+		noChecksumOpts := append([]Option{}, opts...)
+		noChecksumOpts = append(noChecksumOpts, WithoutChecksum(), WithChecksumWords(0))
+		encoder, err := asDictionary(NewDictionary(Bip39Dictionary, noChecksumOpts...))
+		assert.NoError(t, err)
+
+		for _, data := range [][]byte{{}, []byte("nice!"), randomBytes(t, 33)} {
+			mnemonic, err := encoder.Encode(data)
+			assert.NoError(t, err)
+
+			decoded, err := d.DecodeNoChecksum(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+
+			decoded, err = encoder.DecodeNoChecksum(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+		}
+	}
+}
+
+func TestDic_DecodeNoChecksum_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	_, err = d.DecodeNoChecksum([]string{})
+	assert.Error(t, err)
+
+	_, err = d.DecodeNoChecksum([]string{"abandon", "WTF"})
+	assert.Error(t, err)
+
+	// tail length 15 does not fit 11 bits words
+	_, err = d.DecodeNoChecksum([]string{Bip39Dictionary[15], "abandon"})
+	assert.Error(t, err)
+}
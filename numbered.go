@@ -0,0 +1,99 @@
+package recode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatNumbered formats the mnemonic as numbered lines for printable backups,
+// e.g. "1. festival\n2. among\n3. way".
+func FormatNumbered(mnemonic []string) string {
+	var b strings.Builder
+	for i, word := range mnemonic {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(strconv.Itoa(i + 1))
+		b.WriteString(". ")
+		b.WriteString(word)
+	}
+
+	return b.String()
+}
+
+// ParseNumbered parses the mnemonic formatted by FormatNumbered.
+// Every non-empty line should be a number followed by ".", ")" or "-"
+// and a word, e.g. "1. festival", "1) festival" or "1 - festival".
+// Lines could be in any order, words are placed by their numbers,
+// numbers should be exactly 1..N without gaps and duplicates.
+// Words should not contain whitespace, for dictionaries WithSeparator
+// use Dictionary.ParseNumbered.
+func ParseNumbered(s string) ([]string, error) {
+	return parseNumbered(s, strings.Fields)
+}
+
+// ParseNumbered is ParseNumbered for words containing whitespace,
+// the word of a line is split as in DecodeString, see WithSeparator.
+func (d *Dictionary) ParseNumbered(s string) ([]string, error) {
+	return parseNumbered(s, d.config.split)
+}
+
+// parseNumbered is ParseNumbered with the words of a line split by split
+func parseNumbered(s string, split func(string) []string) ([]string, error) {
+	byNumber := map[int]string{}
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		num, word, err := parseNumberedLine(line, split)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if _, ok := byNumber[num]; ok {
+			return nil, fmt.Errorf("line %d: duplicate number %d", i+1, num)
+		}
+		byNumber[num] = word
+	}
+
+	mnemonic := make([]string, len(byNumber))
+	for num, word := range byNumber {
+		if num < 1 || num > len(mnemonic) {
+			return nil, fmt.Errorf("number %d out of range [1, %d]", num, len(mnemonic))
+		}
+		mnemonic[num-1] = word
+	}
+
+	return mnemonic, nil
+}
+
+// parseNumberedLine splits "1. word" into 1 and "word"
+func parseNumberedLine(line string, split func(string) []string) (int, string, error) {
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 {
+		return 0, "", fmt.Errorf("missing number in %q", line)
+	}
+
+	num, err := strconv.Atoi(line[:digits])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid number in %q: %w", line, err)
+	}
+
+	rest := strings.TrimLeft(line[digits:], " \t")
+	if rest == "" || strings.IndexByte(".)-", rest[0]) < 0 {
+		return 0, "", fmt.Errorf("missing \".\", \")\" or \"-\" after number in %q", line)
+	}
+
+	fields := split(rest[1:])
+	if len(fields) != 1 {
+		return 0, "", fmt.Errorf("expected exactly one word in %q", line)
+	}
+
+	return num, fields[0], nil
+}
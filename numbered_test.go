@@ -0,0 +1,95 @@
+package recode
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNumbered(t *testing.T) {
+	assert.Equal(t, "1. festival\n2. among\n3. way", FormatNumbered([]string{"festival", "among", "way"}))
+	assert.Equal(t, "", FormatNumbered(nil))
+}
+
+func TestParseNumbered(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{"dots", "1. festival\n2. among\n3. way", []string{"festival", "among", "way"}, false},
+		{"parens", "1) festival\n2) among\n3) way", []string{"festival", "among", "way"}, false},
+		{"dashes", "1 - festival\n2 - among\n3 - way", []string{"festival", "among", "way"}, false},
+		{"mixed and spaces", "\n  1.festival \r\n\n2 )  among\n3- way\n\n", []string{"festival", "among", "way"}, false},
+		{"reordered", "3. way\n1. festival\n2. among", []string{"festival", "among", "way"}, false},
+		{"empty", "", []string{}, false},
+		{"no number", "festival", nil, true},
+		{"no delimiter", "1 festival", nil, true},
+		{"no word", "1.", nil, true},
+		{"two words", "1. festival among", nil, true},
+		{"duplicate", "1. festival\n1. among", nil, true},
+		{"gap", "1. festival\n3. way", nil, true},
+		{"zero", "0. festival", nil, true},
+		{"huge number", "99999999999999999999. festival", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNumbered(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNumbered_RoundTrip(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 32)
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	parsed, err := ParseNumbered(FormatNumbered(mnemonic))
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, parsed)
+
+	decoded, err := d.Decode(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestDic_ParseNumbered_Separator(t *testing.T) {
+	words := make([]string, 16)
+	for i := range words {
+		words[i] = fmt.Sprintf("ice cream %d", i)
+	}
+
+	d, err := asDictionary(NewDictionary(words, WithSeparator(",")))
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 16)
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	parsed, err := d.ParseNumbered(FormatNumbered(mnemonic))
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, parsed)
+
+	decoded, err := d.Decode(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = d.ParseNumbered("1. ice cream 1, ice cream 2")
+	assert.Error(t, err)
+
+	_, err = ParseNumbered(FormatNumbered(mnemonic))
+	assert.Error(t, err)
+}
@@ -0,0 +1,416 @@
+package recode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"maps"
+	"math/bits"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Option configures a dictionary created by NewDictionary.
+type Option func(c *config)
+
+type config struct {
+	selfVerify    bool
+	maxDecodeWork int
+
+	stopOnFirstDuplicate bool
+
+	hash            func() hash.Hash
+	hashName        string
+	caseInsensitive bool
+	normalization   func(string) string
+	withoutChecksum bool
+
+	hasVersion bool
+	version    int
+
+	domain []byte
+
+	wordIndex bool
+
+	validUTF8 bool
+
+	wholeBytes bool
+
+	progress func(bytesProcessed int64)
+
+	checksumWords int
+
+	entropyLengths []int
+
+	strictLength bool
+
+	separator string
+
+	maxBits int
+
+	bitOrder BitOrder
+
+	aliases map[string]string
+
+	compression bool
+
+	observer Observer
+
+	payloadPrefix []byte
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		hash:     sha256.New,
+		hashName: "sha256",
+		maxBits:  maxBitsPerWord,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// normalize returns the form of the word used for lookups
+func (c config) normalize(word string) string {
+	if c.normalization != nil {
+		word = c.normalization(word)
+	}
+	if c.caseInsensitive {
+		word = strings.ToLower(word)
+	}
+
+	return word
+}
+
+// orderBits converts a byte between MSBFirst and the configured bit order,
+// it is its own inverse, so it is used both for encoding and decoding
+func (c config) orderBits(b byte) byte {
+	if c.bitOrder == LSBFirst {
+		return bits.Reverse8(b)
+	}
+
+	return b
+}
+
+// checkMaxBits checks that a dictionary of bitsPerWord bits is allowed, see WithMaxBits
+func (c config) checkMaxBits(bitsPerWord int) error {
+	if c.maxBits < 1 || c.maxBits > maxBitsPerWord {
+		return fmt.Errorf("max bits %d is out of range [1, %d]", c.maxBits, maxBitsPerWord)
+	}
+
+	if bitsPerWord > c.maxBits {
+		return fmt.Errorf("dictionary of 2^%d words is too large, at most 2^%d words are allowed", bitsPerWord, c.maxBits)
+	}
+
+	return nil
+}
+
+// WithSelfVerify makes Encode decode its own output and compare it
+// with the input before returning it.
+// Mismatch is reported as ErrSelfCheckFailed.
+// It costs one Decode per Encode call, but catches any latent encoding
+// bug before a backup is trusted.
+func WithSelfVerify() Option {
+	return func(c *config) {
+		c.selfVerify = true
+	}
+}
+
+// WithMaxDecodeWork limits the number of bits Decode will process.
+// Mnemonics requiring more work are rejected with ErrDecodeTooExpensive
+// before any work is done.
+// Use it when decoding untrusted input, zero means no limit.
+func WithMaxDecodeWork(bits int) Option {
+	return func(c *config) {
+		c.maxDecodeWork = bits
+	}
+}
+
+// WithStopOnFirstDuplicate makes NewDictionary return on the first
+// duplicate word, instead of collecting all of them.
+func WithStopOnFirstDuplicate() Option {
+	return func(c *config) {
+		c.stopOnFirstDuplicate = true
+	}
+}
+
+// WithHash sets the hash function used for the checksum, sha256 by default.
+// Hash should produce at least 2 bytes.
+// A custom hash could not be marshaled to JSON, use WithNamedHash for that.
+func WithHash(h func() hash.Hash) Option {
+	return func(c *config) {
+		c.hash = h
+		c.hashName = ""
+	}
+}
+
+// WithNamedHash sets the hash function for the checksum by its name,
+// "sha256", "sha512" or "crc32". Unlike WithHash, the name is kept,
+// so the dictionary could be marshaled to JSON, see MarshalJSON.
+// NewDictionary returns an error for other names.
+func WithNamedHash(name string) Option {
+	return func(c *config) {
+		c.hash = hashesByName[name]
+		c.hashName = name
+	}
+}
+
+// WithCRC32 uses crc32 (IEEE) instead of sha256 for the checksum,
+// both for the words checksum and for the data checksum.
+// It is faster for small payloads, but it is not a cryptographic hash,
+// so use it only for non-security transports, where a random
+// corruption should be detected. See BenchmarkEncode_Hash.
+func WithCRC32() Option {
+	return WithNamedHash("crc32")
+}
+
+// WithCaseInsensitive makes Decode ignore the case of words.
+// Encode still returns words as they are in the dictionary.
+func WithCaseInsensitive() Option {
+	return func(c *config) {
+		c.caseInsensitive = true
+	}
+}
+
+// WithNormalization sets a function applied to dictionary words and to
+// mnemonic words before lookup, e.g. unicode NFKD normalization.
+// Encode still returns words as they are in the dictionary.
+func WithNormalization(normalize func(string) string) Option {
+	return func(c *config) {
+		c.normalization = normalize
+	}
+}
+
+// WithoutChecksum disables the checksum.
+// The first word of mnemonic stores only the tail length,
+// checksum bits are zero and they are ignored by Decode.
+func WithoutChecksum() Option {
+	return func(c *config) {
+		c.withoutChecksum = true
+	}
+}
+
+// WithVersion makes Encode prepend a word with index n as the format version.
+// Decode checks it and rejects mnemonics with other versions with VersionError.
+// The version word goes before the checksum word:
+//
+//	version word, checksum word, payload words...
+//
+// It is not covered by the checksum, but it has to match exactly.
+func WithVersion(n int) Option {
+	return func(c *config) {
+		c.hasVersion = true
+		c.version = n
+	}
+}
+
+// WithDomain mixes an application specific salt into the checksum:
+//
+//	hash(data || wordsChecksum || salt)
+//
+// So a mnemonic encoded by one application fails the checksum in another one,
+// even if they use the same words. Empty salt is the same as no domain.
+func WithDomain(salt []byte) Option {
+	return func(c *config) {
+		c.domain = bytes.Clone(salt)
+	}
+}
+
+// WithWordIndex makes Decode find words in a flat open addressing table
+// instead of the default map lookups. It costs a bit more memory,
+// but decoding is faster, especially for big dictionaries,
+// see BenchmarkDecode_Index.
+func WithWordIndex() Option {
+	return func(c *config) {
+		c.wordIndex = true
+	}
+}
+
+// WithValidUTF8 makes NewDictionary reject words which are not valid utf-8.
+// Such words could not be safely printed or split, so use it
+// for text mnemonics. Binary words are safe only for non-text transports.
+func WithValidUTF8() Option {
+	return func(c *config) {
+		c.validUTF8 = true
+	}
+}
+
+// WithWholeBytesOnly is for fixed size entropy, e.g. bip39 style wallets.
+// The first word does not store the tail length, all its bits are the checksum,
+// and the data length is restored from the number of words.
+// It works only if the padding of the last word is shorter than a byte,
+// otherwise Encode returns ErrNotAligned. So with 8 bits or smaller words
+// any whole bytes data could be encoded, but e.g. with 11 bits words
+// 16 bytes are fine, and 32 bytes need 8 bits of padding and are rejected.
+// Mnemonics are not compatible with the default mode.
+func WithWholeBytesOnly() Option {
+	return func(c *config) {
+		c.wholeBytes = true
+	}
+}
+
+// WithProgress sets a callback reporting progress of the streaming
+// NewWriter and DecodeReader, e.g. for a progress bar.
+// It is called every 64KiB of processed data, not per byte,
+// and once with the full size when the operation succeeds.
+// Calls are made synchronously, so the callback should be cheap.
+func WithProgress(fn func(bytesProcessed int64)) Option {
+	return func(c *config) {
+		c.progress = fn
+	}
+}
+
+// WithChecksumWords spreads the checksum over n leading words, 1 by default.
+// The first word keeps the tail length, next n-1 words are the checksum only:
+//
+//	checksum word, n-1 checksum words, payload words...
+//
+// Their bits follow the checksum bits of the first word in the hash.
+// It is useful for small dictionaries, e.g. for the binary dictionary
+// every word is a bit, so the default checksum is just 1 bit.
+// Checksum should fit into 64 bits and into the hash size.
+func WithChecksumWords(n int) Option {
+	return func(c *config) {
+		c.checksumWords = n
+	}
+}
+
+// WithEntropyLengths limits lengths in bytes of entropy accepted by EncodeEntropy,
+// e.g. WithEntropyLengths(Bip39EntropyLengths...) for bip39 style wallets.
+// Encode is not limited.
+func WithEntropyLengths(lengths ...int) Option {
+	return func(c *config) {
+		c.entropyLengths = slices.Clone(lengths)
+	}
+}
+
+// WithStrictLength makes Decode check that the payload is whole bytes,
+// as it is for everything encoded with Encode, and reject mnemonics
+// with leftover bits with ErrTrailingWords. So an accidentally repeated
+// or extra word is reported precisely, instead of a generic checksum error.
+// Only for dictionaries with 8 bits words an extra word adds a whole byte,
+// then the checksum is the only protection.
+// DecodeBits is not affected, as its payload could be any number of bits.
+func WithStrictLength() Option {
+	return func(c *config) {
+		c.strictLength = true
+	}
+}
+
+// WithCompression deflates data in Encode and inflates it back in Decode,
+// if it makes the payload shorter, e.g. for text, where every word counts.
+// A one byte flag and the original length go in front of the payload,
+// like in EncodeCompressed, so incompressible data grows only by them.
+// It applies to the byte APIs: Encode, EncodeAppend, Encoder, EncodeSeq,
+// Decode, DecodeDetailed, DecodeInto, DecodeExactBytes, batches, streams,
+// EncodeMulti and DecodeMulti, where every chunk is compressed on its own,
+// and everything built on them. Bit APIs, like EncodeBits and EncodeUint64,
+// and length helpers, like DecodedLen and EncodedLen, work with the raw payload.
+func WithCompression() Option {
+	return func(c *config) {
+		c.compression = true
+	}
+}
+
+// WithPayloadPrefix makes Encode prepend prefix to the data
+// and Decode verify and strip it, returning ErrWrongPrefix on mismatch,
+// e.g. to tag mnemonics with an application or format version.
+// Unlike WithDomain the prefix is stored in the mnemonic, so it takes words,
+// but it could be read back. With WithCompression the prefix goes
+// before the compressed data, so it is never compressed.
+// It applies to the same APIs as WithCompression.
+func WithPayloadPrefix(prefix []byte) Option {
+	return func(c *config) {
+		c.payloadPrefix = bytes.Clone(prefix)
+	}
+}
+
+// Observer receives metrics of Encode and Decode, e.g. for Prometheus, see WithObserver.
+// Its methods are called synchronously, so they should be cheap
+// and safe for concurrent use, if the dictionary is shared.
+type Observer interface {
+	// EncodeDone is called after a successful Encode
+	EncodeDone(words, bytes int, d time.Duration)
+	// DecodeDone is called after a successful Decode
+	DecodeDone(words, bytes int, d time.Duration)
+	// DecodeError is called when Decode fails
+	DecodeError(err error)
+}
+
+// WithObserver sets an observer called after every Encode, EncodeAppend,
+// Encoder.Encode and Decode, once per call.
+// Other APIs, like EncodeString or Repair, are not reported,
+// neither is self verification of WithSelfVerify.
+// Without an observer no time is measured.
+func WithObserver(o Observer) Option {
+	return func(c *config) {
+		c.observer = o
+	}
+}
+
+// WithSeparator sets the separator between words for EncodeString, DecodeString,
+// NewWriter and DecodeReader, instead of whitespace.
+// So words could contain spaces, e.g. two word phrases like "ice cream":
+//
+//	rec, _ := NewDictionary(words, WithSeparator(","))
+//	rec.(*Dictionary).DecodeString("ice cream, apple pie") // ["ice cream", "apple pie"]
+//
+// Whitespace around words is ignored. NewDictionary returns an error
+// if any word contains the separator. Empty separator means whitespace.
+func WithSeparator(sep string) Option {
+	return func(c *config) {
+		c.separator = sep
+	}
+}
+
+// WithMaxBits limits the dictionary size to 2^bits words, 16 by default,
+// which is the largest size supported by the format.
+// Larger word lists are rejected before anything is allocated for them,
+// so services accepting user supplied word lists could set a lower limit
+// to bound memory and time spent on construction.
+func WithMaxBits(bits int) Option {
+	return func(c *config) {
+		c.maxBits = bits
+	}
+}
+
+// BitOrder is the order in which bits of every byte are packed into words,
+// see WithBitOrder.
+type BitOrder int
+
+const (
+	// MSBFirst packs the most significant bit of every byte first, it is the default.
+	MSBFirst BitOrder = iota
+	// LSBFirst packs the least significant bit of every byte first.
+	LSBFirst
+)
+
+// WithBitOrder sets the order in which bits of every byte are packed into words,
+// MSBFirst by default, e.g. LSBFirst for systems packing bits the other way.
+// Bytes are still taken in order, and the checksum covers the same bytes,
+// so only payload words differ:
+//
+//	MSBFirst: 0b00000001 -> 0000000 1...
+//	LSBFirst: 0b00000001 -> 1000000 0...
+func WithBitOrder(order BitOrder) Option {
+	return func(c *config) {
+		c.bitOrder = order
+	}
+}
+
+// WithAliases adds alternate spellings accepted by Decode, mapped to
+// their canonical dictionary words, e.g. {"grey": "gray"}.
+// Encode always uses canonical words, and aliases do not change
+// the dictionary checksum, see Fingerprint.
+// NewDictionary returns an error if an alias collides with
+// a dictionary word or another alias, or its target is not in the dictionary.
+// Aliases are normalized as words, see WithCaseInsensitive and WithNormalization.
+func WithAliases(aliases map[string]string) Option {
+	return func(c *config) {
+		c.aliases = maps.Clone(aliases)
+	}
+}
@@ -0,0 +1,810 @@
+package recode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSelfVerify(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary, WithSelfVerify())
+	assert.NoError(t, err)
+
+	got, err := d.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+}
+
+func TestWithSelfVerify_Fault(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithSelfVerify()))
+	assert.NoError(t, err)
+
+	// break encoding side of the dictionary only
+	d.words[0], d.words[1] = d.words[1], d.words[0]
+
+	got, err := d.Encode([]byte("1"))
+	assert.ErrorIs(t, err, ErrSelfCheckFailed)
+	assert.Nil(t, got)
+
+	// without self verify broken mnemonic is returned
+	d.config.selfVerify = false
+	got, err = d.Encode([]byte("1"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got)
+}
+
+func TestWithMaxDecodeWork(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithMaxDecodeWork(7*11)))
+	assert.NoError(t, err)
+
+	got, err := d.Decode([]string{"festival", "among", "way", "lemon", "extra", "actor", "betray"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7, 255, 1, 255, 40, 128, 42, 42}, got)
+
+	_, err = d.Decode([]string{"festival", "among", "way", "lemon", "extra", "actor", "betray", "betray"})
+	assert.ErrorIs(t, err, ErrDecodeTooExpensive)
+
+	_, err = d.DecodeInto(make([]string, 1000000), make([]byte, 10))
+	assert.ErrorIs(t, err, ErrDecodeTooExpensive)
+}
+
+func TestWithHash(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithHash(sha512.New)))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "festival", got[0])
+	// only checksum word differs
+	assert.Equal(t, []string{"among", "way", "lemon", "extra", "actor", "betray"}, got[1:])
+
+	dec, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	sum, err := d.LastChecksum(data)
+	assert.NoError(t, err)
+	assert.Len(t, sum, sha512.Size)
+
+	sha, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+	assert.NotEqual(t, sha.Fingerprint(), d.Fingerprint())
+
+	_, err = sha.Decode(got)
+	assert.Error(t, err)
+
+	_, err = NewDictionary(Bip39Dictionary, WithHash(func() hash.Hash { return shortHash{sha256.New()} }))
+	assert.Error(t, err)
+}
+
+type shortHash struct {
+	hash.Hash
+}
+
+func (shortHash) Size() int { return 1 }
+
+func TestWithCaseInsensitive(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary, WithCaseInsensitive())
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+	dec, err := d.Decode([]string{"Festival", "AMONG", "way", "lemon", "eXtRa", "actor", "betray"})
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	sensitive, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+	_, err = sensitive.Decode([]string{"Festival", "AMONG", "way", "lemon", "eXtRa", "actor", "betray"})
+	assert.Error(t, err)
+
+	// case only duplicates
+	_, err = NewDictionary([]string{"foo", "bar", "Foo", "buzz"}, WithCaseInsensitive())
+	assert.Error(t, err)
+
+	_, err = NewDictionary([]string{"foo", "bar", "Foo", "buzz"})
+	assert.NoError(t, err)
+}
+
+func TestWithNormalization(t *testing.T) {
+	stripDashes := func(s string) string {
+		return strings.ReplaceAll(s, "-", "")
+	}
+
+	d, err := NewDictionary([]string{"fo-o", "bar", "fizz", "buzz"}, WithNormalization(stripDashes))
+	assert.NoError(t, err)
+
+	got, err := d.Encode([]byte("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fizz", "fo-o", "buzz", "fo-o", "bar"}, got)
+
+	dec, err := d.Decode([]string{"fi-zz", "foo", "buzz", "f-o-o", "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), dec)
+
+	_, err = NewDictionary([]string{"foo", "bar", "f-o-o", "buzz"}, WithNormalization(stripDashes))
+	assert.Error(t, err)
+}
+
+func TestWithoutChecksum(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithoutChecksum()))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, d.ChecksumBits())
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got, err := d.Encode(data)
+	assert.NoError(t, err)
+	// only tail length 9 is in the first word
+	assert.Equal(t, []string{Bip39Dictionary[9], "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+	dec, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	// corruption is not detected
+	got[1] = "abandon"
+	_, err = d.Decode(got)
+	assert.NoError(t, err)
+}
+
+func TestWithVersion(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(1)))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ability", "festival", "among", "way", "lemon", "extra", "actor", "betray"}, got)
+
+	dec, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	l, err := d.DecodedLen(got)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), l)
+
+	dec, err = d.DecodeReader(strings.NewReader(strings.Join(got, " ")))
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	chunks := [][]byte{data, {}, []byte("nice!")}
+	multi, err := d.EncodeMulti(chunks)
+	assert.NoError(t, err)
+	decChunks, err := d.DecodeMulti(multi)
+	assert.NoError(t, err)
+	assert.Equal(t, chunks, decChunks)
+
+	// other version
+	v2, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(2)))
+	assert.NoError(t, err)
+
+	_, err = v2.Decode(got)
+	var versionErr *VersionError
+	assert.ErrorAs(t, err, &versionErr)
+	assert.Equal(t, &VersionError{Version: 1, Expected: 2}, versionErr)
+
+	_, err = v2.DecodeReader(strings.NewReader(strings.Join(got, " ")))
+	assert.ErrorAs(t, err, &versionErr)
+
+	// no version word
+	_, err = d.Decode(got[1:])
+	assert.Error(t, err)
+
+	_, err = d.Decode([]string{"ability"})
+	assert.Error(t, err)
+
+	_, err = d.DecodeReader(strings.NewReader("ability"))
+	assert.Error(t, err)
+}
+
+func TestWithVersion_OutOfRange(t *testing.T) {
+	_, err := NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithVersion(4))
+	assert.Error(t, err)
+
+	_, err = NewDictionary([]string{"foo", "bar", "fizz", "buzz"}, WithVersion(-1))
+	assert.Error(t, err)
+}
+
+func TestWithNormalization_Separator(t *testing.T) {
+	toSpace := func(s string) string {
+		return strings.ReplaceAll(s, "_", " ")
+	}
+
+	_, err := NewDictionary([]string{"foo", "bar", "ice_cream", "buzz"})
+	assert.NoError(t, err)
+
+	_, err = NewDictionary([]string{"foo", "bar", "ice_cream", "buzz"}, WithNormalization(toSpace))
+	assert.EqualError(t, err, `word "ice_cream" at 2 contains separator ' '`)
+}
+
+func TestWithDomain(t *testing.T) {
+	plain, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+	appA, err := NewDictionary(Bip39Dictionary, WithDomain([]byte("app A")))
+	assert.NoError(t, err)
+	appB, err := NewDictionary(Bip39Dictionary, WithDomain([]byte("app B")))
+	assert.NoError(t, err)
+	empty, err := NewDictionary(Bip39Dictionary, WithDomain(nil))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+
+	mnemonic, err := appA.Encode(data)
+	assert.NoError(t, err)
+
+	dec, err := appA.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	_, err = appB.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = plain.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	// only the checksum word differs
+	other, err := appB.Encode(data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, mnemonic[0], other[0])
+	assert.Equal(t, mnemonic[1:], other[1:])
+
+	// empty domain is the same as no domain
+	want, err := plain.Encode(data)
+	assert.NoError(t, err)
+	got, err := empty.Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestWithValidUTF8(t *testing.T) {
+	words := []string{"foo", "bar", "fi\xffzz", "buzz"}
+
+	_, err := NewDictionary(words)
+	assert.NoError(t, err)
+
+	_, err = NewDictionary(words, WithValidUTF8())
+	assert.EqualError(t, err, `word "fi\xffzz" at 2 is not valid utf-8, binary words are safe only for non-text transports`)
+
+	_, err = NewDictionary(Bip39Dictionary, WithValidUTF8())
+	assert.NoError(t, err)
+	_, err = NewDictionary(fruits, WithValidUTF8())
+	assert.NoError(t, err)
+}
+
+func TestWithWholeBytesOnly(t *testing.T) {
+	d, err := asDictionary(NewDictionary(fruits, WithWholeBytesOnly()))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, d.ChecksumBits())
+
+	// fruits wallet 128 bit vector
+	data, err := hex.DecodeString("8afc9484b168970fbf9d8cc394405174")
+	assert.NoError(t, err)
+
+	plain, err := asDictionary(NewDictionary(fruits))
+	assert.NoError(t, err)
+	want, err := plain.Encode(data)
+	assert.NoError(t, err)
+
+	got, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Len(t, got, 27)
+	assert.Equal(t, want[1:], got[1:])
+
+	decoded, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	for l := 0; l < 64; l++ {
+		data := randomBytes(t, l)
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		decoded, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic, " ")))
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+
+	_, err = d.EncodeBits([]byte{0xff, 0xff}, 12)
+	assert.ErrorIs(t, err, ErrNotAligned)
+}
+
+func TestWithWholeBytesOnly_NotAligned(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithWholeBytesOnly()))
+	assert.NoError(t, err)
+	assert.Equal(t, 11, d.ChecksumBits())
+
+	for _, l := range []int{16, 20, 24, 28} {
+		data := randomBytes(t, l)
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+		assert.Len(t, mnemonic, 1+(l*8+10)/11)
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+
+	// 256 bits need 8 bits of padding, 33 bytes could not be told apart
+	_, err = d.Encode(randomBytes(t, 32))
+	assert.EqualError(t, err, "data is not aligned for whole bytes mode: 32 bytes need 8 bits of padding")
+	assert.ErrorIs(t, err, ErrNotAligned)
+}
+
+func TestWithChecksumWords_Binary(t *testing.T) {
+	d, err := asDictionary(NewBinaryDictionary(WithChecksumWords(16)))
+	assert.NoError(t, err)
+	assert.Equal(t, 16, d.ChecksumBits())
+
+	data := []byte("nice!")
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, 16+len(data)*8)
+	assert.Equal(t, len(mnemonic), d.EncodedLen(len(data)))
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	decoded, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic, " ")))
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	// every single bit flip is detected, in the checksum and in the payload
+	for i := range mnemonic {
+		flipped := slices.Clone(mnemonic)
+		if flipped[i] == "0" {
+			flipped[i] = "1"
+		} else {
+			flipped[i] = "0"
+		}
+
+		_, err = d.Decode(flipped)
+		assert.ErrorIs(t, err, ErrInvalidChecksum, "bit %d", i)
+
+		_, err = d.DecodeReader(strings.NewReader(strings.Join(flipped, " ")))
+		assert.ErrorIs(t, err, ErrInvalidChecksum, "bit %d", i)
+	}
+
+	_, err = d.Decode(mnemonic[:15])
+	assert.Error(t, err)
+	_, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic[:15], " ")))
+	assert.Error(t, err)
+}
+
+func TestWithChecksumWords(t *testing.T) {
+	plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithChecksumWords(3), WithVersion(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, 7+2*11, d.ChecksumBits())
+
+	for l := 0; l < 40; l++ {
+		data := randomBytes(t, l)
+		want, err := plain.Encode(data)
+		assert.NoError(t, err)
+
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+		assert.Len(t, mnemonic, d.EncodedLen(l))
+		// version, the same first word, 2 checksum words, the same payload
+		assert.Equal(t, want[0], mnemonic[1])
+		assert.Equal(t, want[1:], mnemonic[4:])
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		chunks, err := d.DecodeMulti(mustEncodeMulti(t, d, [][]byte{data, data}))
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{data, data}, chunks)
+	}
+
+	fixed, err := d.EncodeFixed([]byte("nice!"), 12)
+	assert.NoError(t, err)
+	assert.Len(t, fixed, 12)
+	decoded, err := d.DecodeFixed(fixed)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("nice!"), decoded)
+}
+
+func TestWithChecksumWords_Error(t *testing.T) {
+	_, err := NewBinaryDictionary(WithChecksumWords(-1))
+	assert.Error(t, err)
+	_, err = NewBinaryDictionary(WithChecksumWords(65))
+	assert.Error(t, err)
+	_, err = NewBinaryDictionary(WithChecksumWords(64))
+	assert.NoError(t, err)
+	_, err = NewBinaryDictionary(WithChecksumWords(2), WithoutChecksum())
+	assert.Error(t, err)
+	_, err = NewBinaryDictionary(WithChecksumWords(32), WithHash(func() hash.Hash { return crc32.NewIEEE() }))
+	assert.NoError(t, err)
+	_, err = NewBinaryDictionary(WithChecksumWords(33), WithHash(func() hash.Hash { return crc32.NewIEEE() }))
+	assert.Error(t, err)
+}
+
+func mustEncodeMulti(t *testing.T, d *Dictionary, chunks [][]byte) []string {
+	t.Helper()
+
+	mnemonic, err := d.EncodeMulti(chunks)
+	assert.NoError(t, err)
+
+	return mnemonic
+}
+
+func TestWithCRC32(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCRC32()))
+	assert.NoError(t, err)
+	crc, err := asDictionary(NewDictionary(Bip39Dictionary, WithHash(func() hash.Hash { return crc32.NewIEEE() })))
+	assert.NoError(t, err)
+	sha, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, sha.Fingerprint(), d.Fingerprint())
+	assert.Equal(t, crc.Fingerprint(), d.Fingerprint())
+	assert.Len(t, d.Fingerprint(), 8)
+
+	for l := 0; l < 40; l++ {
+		data := randomBytes(t, l)
+		got, err := d.Encode(data)
+		assert.NoError(t, err)
+
+		// deterministic
+		want, err := crc.Encode(data)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		dec, err := d.Decode(got)
+		assert.NoError(t, err)
+		assert.Equal(t, data, dec)
+	}
+}
+
+func TestWithStrictLength(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithStrictLength()))
+	assert.NoError(t, err)
+	plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	dec, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	// repeated word
+	extra := slices.Insert(slices.Clone(mnemonic), 3, mnemonic[3])
+	_, err = d.Decode(extra)
+	assert.ErrorIs(t, err, ErrTrailingWords)
+	assert.EqualError(t, err, "mnemonic has trailing words: 3 bits left after 9 bytes")
+	_, err = d.DecodeReader(strings.NewReader(strings.Join(extra, " ")))
+	assert.ErrorIs(t, err, ErrTrailingWords)
+	_, err = d.DecodeDetailed(extra)
+	assert.ErrorIs(t, err, ErrTrailingWords)
+
+	// without strict mode it is just a checksum error
+	_, err = plain.Decode(extra)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	// bits are not affected
+	bitsMnemonic, err := d.EncodeBits([]byte{0xff, 0xf0}, 12)
+	assert.NoError(t, err)
+	bits, bitLen, err := d.DecodeBits(bitsMnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xf0}, bits)
+	assert.Equal(t, 12, bitLen)
+}
+
+func TestWithSeparator(t *testing.T) {
+	words := []string{"ice cream", "apple pie", "hot dog", "fish and chips"}
+
+	_, err := NewDictionary(words)
+	assert.EqualError(t, err, `word "ice cream" at 0 contains separator ' '`)
+
+	d, err := asDictionary(NewDictionary(words, WithSeparator(",")))
+	assert.NoError(t, err)
+
+	data := []byte("nice!")
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	phrase, err := d.EncodeString(data)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join(mnemonic, ","), phrase)
+
+	dec, err := d.DecodeString(phrase)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	// whitespace around words and empty words are ignored
+	loose := " " + strings.Join(mnemonic, " ,\n ") + ",, "
+	dec, err = d.DecodeString(loose)
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	var buf bytes.Buffer
+	w := d.NewWriter(&buf)
+	_, err = w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, phrase, buf.String())
+
+	dec, err = d.DecodeReader(iotest.OneByteReader(strings.NewReader(loose)))
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+
+	// multi byte separator
+	d, err = asDictionary(NewDictionary(words, WithSeparator(" | ")))
+	assert.NoError(t, err)
+	dec, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic, " | ")))
+	assert.NoError(t, err)
+	assert.Equal(t, data, dec)
+}
+
+func TestWithSeparator_Error(t *testing.T) {
+	_, err := NewDictionary([]string{"foo", "bar", "fizz,buzz", "buzz"}, WithSeparator(","))
+	assert.EqualError(t, err, `word "fizz,buzz" at 2 contains separator ","`)
+
+	_, err = NewDictionary([]string{"foo", "bar", "fizz_buzz", "buzz"}, WithSeparator(","), WithNormalization(func(s string) string {
+		return strings.ReplaceAll(s, "_", ",")
+	}))
+	assert.EqualError(t, err, `word "fizz_buzz" at 2 contains separator ","`)
+}
+
+func TestWithMaxBits(t *testing.T) {
+	// words are not even checked, so nothing is allocated for them
+	_, err := NewDictionary(make([]string, 1<<17))
+	assert.EqualError(t, err, "dictionary of 2^17 words is too large, at most 2^16 words are allowed")
+
+	_, err = NewDictionary(Bip39Dictionary, WithMaxBits(8))
+	assert.EqualError(t, err, "dictionary of 2^11 words is too large, at most 2^8 words are allowed")
+
+	_, err = NewDictionary(Bip39Dictionary, WithMaxBits(11))
+	assert.NoError(t, err)
+
+	_, err = NewDictionary(sequentialWords(1<<16), WithMaxBits(16))
+	assert.NoError(t, err)
+
+	_, err = NewDictionary(Bip39Dictionary, WithMaxBits(20))
+	assert.EqualError(t, err, "max bits 20 is out of range [1, 16]")
+
+	_, err = NewDictionaryFromMapping(map[string]int{"foo": 0, "bar": 1, "fizz": 2, "buzz": 3}, WithMaxBits(1))
+	assert.Error(t, err)
+}
+
+func TestWithBitOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		order BitOrder
+		data  []byte
+		want  []string
+	}{
+		{"hex msb", HexDictionary, MSBFirst, []byte{0x01, 0xa4}, []string{"0", "0", "1", "a", "4"}},
+		{"hex lsb", HexDictionary, LSBFirst, []byte{0x01, 0xa4}, []string{"0", "8", "0", "2", "5"}},
+		{"bip39 msb", Bip39Dictionary, MSBFirst, []byte{7, 255, 1, 255, 40, 128, 42, 42}, []string{"festival", "among", "way", "lemon", "extra", "actor", "betray"}},
+		// the checksum covers the same bytes, so the first word is the same
+		{"bip39 lsb", Bip39Dictionary, LSBFirst, []byte{7, 255, 1, 255, 40, 128, 42, 42}, []string{"festival", "thought", "winter", "divert", "chimney", "best", "clerk"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDictionary(tt.words, WithBitOrder(tt.order))
+			assert.NoError(t, err)
+
+			mnemonic, err := d.Encode(tt.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, mnemonic)
+
+			decoded, err := d.Decode(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.data, decoded)
+		})
+	}
+}
+
+func TestWithBitOrder_RoundTrip(t *testing.T) {
+	for _, words := range [][]string{BinaryDictionary, fruits, Bip39Dictionary, sequentialWords(1 << 13)} {
+		d, err := asDictionary(NewDictionary(words, WithBitOrder(LSBFirst)))
+		assert.NoError(t, err)
+
+		for l := range 40 {
+			data := randomBytes(t, l)
+
+			mnemonic, err := d.Encode(data)
+			assert.NoError(t, err)
+
+			decoded, err := d.Decode(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+
+			decoded, err = d.DecodeReader(strings.NewReader(strings.Join(mnemonic, " ")))
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+
+			seq := []string{}
+			for word, err := range d.EncodeSeq(data) {
+				assert.NoError(t, err)
+				seq = append(seq, word)
+			}
+			assert.Equal(t, mnemonic, seq)
+		}
+	}
+}
+
+func TestWithBitOrder_Bits(t *testing.T) {
+	d, err := asDictionary(NewDictionary(BinaryDictionary, WithBitOrder(LSBFirst)))
+	assert.NoError(t, err)
+
+	// the first 3 bits are the low ones
+	mnemonic, err := d.EncodeBits([]byte{0b11110101}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "0", "1"}, mnemonic[1:])
+
+	data, bitLen, err := d.DecodeBits(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, bitLen)
+	assert.Equal(t, []byte{0b00000101}, data)
+
+	for _, v := range []uint64{0, 1, 5, 256, math.MaxUint64} {
+		mnemonic, err := d.EncodeUint64(v)
+		assert.NoError(t, err)
+
+		got, err := d.DecodeUint64(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, v, got)
+	}
+}
+
+func TestWithAliases(t *testing.T) {
+	words := []string{"gray", "red", "green", "blue"}
+	for _, opts := range [][]Option{
+		{WithAliases(map[string]string{"grey": "gray", "Rot": "red"})},
+		{WithAliases(map[string]string{"grey": "gray", "Rot": "red"}), WithWordIndex()},
+	} {
+		d, err := asDictionary(NewDictionary(words, opts...))
+		assert.NoError(t, err)
+
+		data := []byte("nice!")
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+		assert.NotContains(t, mnemonic, "grey")
+
+		withAliases := slices.Clone(mnemonic)
+		for i, word := range withAliases {
+			switch word {
+			case "gray":
+				withAliases[i] = "grey"
+			case "red":
+				withAliases[i] = "Rot"
+			}
+		}
+		assert.NotEqual(t, mnemonic, withAliases)
+
+		decoded, err := d.Decode(withAliases)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		assert.True(t, d.Contains("grey"))
+		// aliases are normalized as words
+		assert.False(t, d.Contains("rot"))
+	}
+
+	plain, err := asDictionary(NewDictionary(words))
+	assert.NoError(t, err)
+	d, err := asDictionary(NewDictionary(words, WithAliases(map[string]string{"grey": "gray"})))
+	assert.NoError(t, err)
+	assert.Equal(t, plain.Fingerprint(), d.Fingerprint())
+}
+
+func TestWithAliases_Error(t *testing.T) {
+	words := []string{"gray", "red", "green", "blue"}
+
+	_, err := NewDictionary(words, WithAliases(map[string]string{"red": "gray"}))
+	assert.EqualError(t, err, `alias "red" collides with a dictionary word`)
+
+	_, err = NewDictionary(words, WithAliases(map[string]string{"grey": "white"}))
+	assert.EqualError(t, err, `alias "grey" target "white" is not in the dictionary`)
+
+	_, err = NewDictionary(words, WithCaseInsensitive(), WithAliases(map[string]string{"Grey": "gray", "grey": "gray"}))
+	assert.EqualError(t, err, `aliases "Grey" and "grey" collide`)
+
+	_, err = NewDictionary(words, WithCaseInsensitive(), WithAliases(map[string]string{"RED": "gray"}))
+	assert.EqualError(t, err, `alias "RED" collides with a dictionary word`)
+
+	_, err = NewDictionary(words, WithAliases(map[string]string{"light gray": "gray"}))
+	assert.EqualError(t, err, `alias "light gray" contains separator ' '`)
+
+	_, err = NewDictionary(words, WithAliases(map[string]string{"": "gray"}))
+	assert.EqualError(t, err, `alias "" is empty`)
+}
+
+type countingObserver struct {
+	encoded, decoded [][2]int
+	errs             []error
+}
+
+func (o *countingObserver) EncodeDone(words, bytes int, d time.Duration) {
+	o.encoded = append(o.encoded, [2]int{words, bytes})
+}
+
+func (o *countingObserver) DecodeDone(words, bytes int, d time.Duration) {
+	o.decoded = append(o.decoded, [2]int{words, bytes})
+}
+
+func (o *countingObserver) DecodeError(err error) {
+	o.errs = append(o.errs, err)
+}
+
+func TestWithObserver(t *testing.T) {
+	o := &countingObserver{}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithObserver(o), WithSelfVerify()))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+
+	_, err = d.Decode(mnemonic)
+	assert.NoError(t, err)
+
+	_, err = d.Decode([]string{"festival", "among", "way"})
+	assert.Error(t, err)
+
+	_, err = d.Decode([]string{"WTF"})
+	assert.Error(t, err)
+
+	// APIs built on Encode and Decode are not reported
+	_, err = d.DecodeString("festival among way")
+	assert.Error(t, err)
+
+	assert.Equal(t, [][2]int{{7, 8}}, o.encoded)
+	assert.Equal(t, [][2]int{{7, 8}}, o.decoded)
+	assert.Len(t, o.errs, 2)
+	assert.ErrorIs(t, o.errs[0], ErrInvalidChecksum)
+}
+
+func TestWithObserver_OncePerCall(t *testing.T) {
+	o := &countingObserver{}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithObserver(o), WithSelfVerify()))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+
+	mnemonic, err := d.EncodeAppend([]string{"prefix"}, data)
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, 8)
+	assert.Equal(t, [][2]int{{7, 8}}, o.encoded)
+
+	enc := d.NewEncoder()
+	_, err = enc.Encode(data)
+	assert.NoError(t, err)
+	_, err = enc.Encode(data[:4])
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{7, 8}, {7, 8}, {4, 4}}, o.encoded)
+
+	broken := slices.Clone(mnemonic[1:])
+	broken[3] = "zoo"
+	assert.NotEmpty(t, d.Repair(broken, 1))
+
+	assert.Empty(t, o.decoded)
+	assert.Empty(t, o.errs)
+}
@@ -0,0 +1,58 @@
+package recode
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// parallelEncodeMinBytes is the payload size from which words are mapped
+// by GOMAXPROCS goroutines, smaller payloads are not worth the fan-out
+const parallelEncodeMinBytes = 1 << 20
+
+// appendEncodedParallel is appendEncoded for big payloads.
+// Data is split into chunks of whole groups of bitsBatchSize bytes,
+// which are exactly 8 words, so chunks never share a word
+// and every worker writes only its own range of the mnemonic.
+// The checksum is calculated meanwhile by the calling goroutine.
+func (d *Dictionary) appendEncodedParallel(mnemonic []string, data []byte, bitLen, workers int) ([]string, error) {
+	start := len(mnemonic)
+	headerLen := d.headerLen()
+	payloadWords := (bitLen + d.bitsBatchSize - 1) / d.bitsBatchSize
+
+	mnemonic = slices.Grow(mnemonic, headerLen+payloadWords)
+	words := mnemonic[start : start+headerLen+payloadWords]
+	payload := words[headerLen:]
+
+	chunkLen := (len(data)/workers/d.bitsBatchSize + 1) * d.bitsBatchSize
+
+	var wg sync.WaitGroup
+	for off := 0; off < len(data) && off*8 < bitLen; off += chunkLen {
+		chunk := data[off:min(off+chunkLen, len(data))]
+		chunkBits := min(len(chunk)*8, bitLen-off*8)
+		first := off * 8 / d.bitsBatchSize
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.appendPayload(payload[first:first], chunk, chunkBits)
+		}()
+	}
+
+	_, err := d.appendHeader(words[:0], data, bitLen)
+	wg.Wait()
+	if err != nil {
+		return mnemonic[:start], err
+	}
+
+	return mnemonic[:start+len(words)], nil
+}
+
+// encodeWorkers returns how many goroutines should map dataLen bytes into words
+func encodeWorkers(dataLen int) int {
+	if dataLen < parallelEncodeMinBytes {
+		return 1
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
@@ -0,0 +1,63 @@
+package recode
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_AppendEncodedParallel(t *testing.T) {
+	for _, words := range [][]string{{"0", "1"}, Bip39Dictionary, sequentialWords(65536)} {
+		for _, opts := range [][]Option{nil, {WithVersion(1), WithChecksumWords(2)}} {
+			d, err := asDictionary(NewDictionary(words, opts...))
+			assert.NoError(t, err)
+
+			for _, size := range []int{1, 16, 100, 1000, 4097} {
+				data := randomBytes(t, size)
+
+				for _, bitLen := range []int{size * 8, size*8 - 3} {
+					serial, err := d.appendHeader(nil, data, bitLen)
+					assert.NoError(t, err)
+					serial = d.appendPayload(serial, data, bitLen)
+
+					for workers := 2; workers <= 8; workers++ {
+						prefix := []string{"keep"}
+						got, err := d.appendEncodedParallel(prefix, data, bitLen, workers)
+						assert.NoError(t, err)
+						assert.Equal(t, "keep", got[0])
+						assert.Equal(t, serial, got[1:], "size %d, bits %d, workers %d", size, bitLen, workers)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDic_AppendEncodedParallel_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithWholeBytesOnly()))
+	assert.NoError(t, err)
+
+	prefix := []string{"keep"}
+	got, err := d.appendEncodedParallel(prefix, make([]byte, 7), 7*8, 4)
+	assert.Error(t, err)
+	assert.Equal(t, prefix, got)
+}
+
+func TestDic_Encode_Parallel(t *testing.T) {
+	// force the fan-out on single CPU machines
+	t.Cleanup(func(prev int) func() {
+		return func() { runtime.GOMAXPROCS(prev) }
+	}(runtime.GOMAXPROCS(4)))
+
+	d, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	data := randomBytes(t, parallelEncodeMinBytes+3)
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
@@ -0,0 +1,48 @@
+package recode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+)
+
+// wrapsPayload reports whether data is transformed into the payload,
+// see wrapPayload
+func (c config) wrapsPayload() bool {
+	return c.compression || len(c.payloadPrefix) > 0
+}
+
+// wrapPayload returns the payload of data, deflated with WithCompression
+// and prefixed with WithPayloadPrefix
+func (c config) wrapPayload(data []byte) ([]byte, error) {
+	payload, err := c.compress(data)
+	if err != nil || len(c.payloadPrefix) == 0 {
+		return payload, err
+	}
+
+	return slices.Concat(c.payloadPrefix, payload), nil
+}
+
+// maxPayloadLen returns the biggest length of wrapPayload output
+// for dataLen bytes of data, it is exact without WithCompression
+func (c config) maxPayloadLen(dataLen int) int {
+	if c.compression {
+		// stored raw with the flag and the length, if deflate does not help
+		var buf [binary.MaxVarintLen64]byte
+		dataLen += 1 + binary.PutUvarint(buf[:], uint64(dataLen))
+	}
+
+	return len(c.payloadPrefix) + dataLen
+}
+
+// unwrapPayload returns the data of payload created by wrapPayload
+func (c config) unwrapPayload(payload []byte) ([]byte, error) {
+	if len(c.payloadPrefix) > 0 {
+		if !bytes.HasPrefix(payload, c.payloadPrefix) {
+			return nil, ErrWrongPrefix
+		}
+		payload = payload[len(c.payloadPrefix):]
+	}
+
+	return c.decompress(payload)
+}
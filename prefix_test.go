@@ -0,0 +1,94 @@
+package recode
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPayloadPrefix(t *testing.T) {
+	prefix := []byte{0xAB, 1}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix(prefix)))
+	assert.NoError(t, err)
+	// the prefix is copied
+	prefix[1] = 2
+
+	plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, data := range [][]byte{{}, []byte("nice!"), randomBytes(t, 33)} {
+		mnemonic, err := d.Encode(data)
+		assert.NoError(t, err)
+		assert.Equal(t, plain.EncodedLen(len(data)+2), len(mnemonic))
+
+		decoded, err := d.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+		assert.Equal(t, len(data) == 0, d.IsEmpty(mnemonic))
+
+		// the prefix is a part of the payload
+		payload, err := plain.Decode(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, append([]byte{0xAB, 1}, data...), payload)
+
+		dst := make([]byte, len(data))
+		n, err := d.DecodeInto(mnemonic, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, data, dst[:n])
+
+		decoded, err = io.ReadAll(d.DecodeReaderFromWords(slices.Values(mnemonic)))
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+}
+
+func TestWithPayloadPrefix_Compression(t *testing.T) {
+	d, err := NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte("v1")), WithCompression())
+	assert.NoError(t, err)
+
+	plain, err := NewDictionary(Bip39Dictionary)
+	assert.NoError(t, err)
+
+	data := []byte(strings.Repeat("nice! ", 50))
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	payload, err := plain.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), payload[:2])
+	assert.Equal(t, compressionFlate, payload[2])
+}
+
+func TestWithPayloadPrefix_Wrong(t *testing.T) {
+	v1, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte("v1"))))
+	assert.NoError(t, err)
+
+	v2, err := asDictionary(NewDictionary(Bip39Dictionary, WithPayloadPrefix([]byte("v2"))))
+	assert.NoError(t, err)
+
+	plain, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := v1.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	_, err = v2.Decode(mnemonic)
+	assert.ErrorIs(t, err, ErrWrongPrefix)
+
+	_, err = v2.DecodeReader(strings.NewReader(strings.Join(mnemonic, " ")))
+	assert.ErrorIs(t, err, ErrWrongPrefix)
+
+	// shorter than the prefix
+	short, err := plain.Encode([]byte("v"))
+	assert.NoError(t, err)
+
+	_, err = v1.Decode(short)
+	assert.ErrorIs(t, err, ErrWrongPrefix)
+}
@@ -0,0 +1,64 @@
+package recode
+
+import "slices"
+
+// Repair tries to fix a mnemonic failing the checksum by substituting words,
+// e.g. to recover a phrase with a transcription error.
+// It returns all the candidates which Decode accepts with the fewest
+// substitutions, up to maxEdits. Unknown words count as substitutions too.
+// If the mnemonic is already valid, only its copy is returned.
+// Returns nil if nothing is found or maxEdits < 1.
+//
+// It is a brute force search, every candidate costs one Decode:
+// len(mnemonic) * dictionary size for maxEdits == 1,
+// e.g. 24 * 2048 ≈ 49k for a bip39 size mnemonic, and
+// C(len(mnemonic), k) * (dictionary size - 1)^k for k edits,
+// so maxEdits > 1 is practical only for short mnemonics or small dictionaries.
+// With a short checksum more than one candidate is expected,
+// e.g. 7 bits of the bip39 size dictionary pass about 1/128 of them,
+// see ChecksumBits and WithChecksumWords.
+func (d *Dictionary) Repair(mnemonic []string, maxEdits int) [][]string {
+	if maxEdits < 1 {
+		return nil
+	}
+
+	if _, err := d.decode(mnemonic); err == nil {
+		return [][]string{slices.Clone(mnemonic)}
+	}
+
+	candidate := slices.Clone(mnemonic)
+	for edits := 1; edits <= maxEdits && edits <= len(mnemonic); edits++ {
+		var fixes [][]string
+		d.repair(candidate, 0, edits, &fixes)
+		if len(fixes) > 0 {
+			return fixes
+		}
+	}
+
+	return nil
+}
+
+// repair substitutes exactly edits words of candidate at positions from and after,
+// and collects candidates accepted by Decode into fixes.
+// candidate is restored on return.
+func (d *Dictionary) repair(candidate []string, from, edits int, fixes *[][]string) {
+	for i := from; i <= len(candidate)-edits; i++ {
+		orig := candidate[i]
+		origIdx, known := d.lookupIdx(orig)
+
+		for idx, word := range d.words {
+			if known && idx == origIdx {
+				continue
+			}
+
+			candidate[i] = word
+			if edits > 1 {
+				d.repair(candidate, i+1, edits-1, fixes)
+			} else if _, err := d.decode(candidate); err == nil {
+				*fixes = append(*fixes, slices.Clone(candidate))
+			}
+		}
+
+		candidate[i] = orig
+	}
+}
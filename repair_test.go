@@ -0,0 +1,65 @@
+package recode
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_Repair(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+
+	for i, word := range []string{"zoo", "unknown"} {
+		broken := slices.Clone(mnemonic)
+		broken[3+i] = word
+
+		_, err = d.Decode(broken)
+		assert.Error(t, err)
+
+		fixes := d.Repair(broken, 1)
+		assert.Contains(t, fixes, mnemonic)
+		for _, fix := range fixes {
+			assert.Len(t, Diff(fix, broken), 1)
+
+			_, err := d.Decode(fix)
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestDic_Repair_Valid(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte("nice!"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]string{mnemonic}, d.Repair(mnemonic, 1))
+	assert.Nil(t, d.Repair(mnemonic, 0))
+}
+
+func TestDic_Repair_TwoEdits(t *testing.T) {
+	words := sequentialWords(16)
+	d, err := asDictionary(NewDictionary(words, WithChecksumWords(3)))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte("ok"))
+	assert.NoError(t, err)
+
+	broken := slices.Clone(mnemonic)
+	broken[2] = "unknown"
+	broken[4] = "unknown"
+
+	assert.Nil(t, d.Repair(broken, 1))
+
+	fixes := d.Repair(broken, 2)
+	assert.Contains(t, fixes, mnemonic)
+	for _, fix := range fixes {
+		assert.Len(t, Diff(fix, broken), 2)
+	}
+}
@@ -0,0 +1,22 @@
+package recode
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"strings"
+)
+
+const (
+	seedIterations = 2048
+	seedLen        = 64
+)
+
+// MnemonicToSeed derives a 64 byte seed from the mnemonic and passphrase
+// with PBKDF2-HMAC-SHA512, 2048 iterations and "mnemonic"+passphrase salt, as in bip39.
+// The seed is compatible with bip39 wallets only for bip39 mnemonics,
+// words from Encode give a different seed than the original bip39 entropy.
+// Words and passphrase are used as is, normalize them to NFKD for non ascii input.
+// Returns an error only in fips140=only mode, which rejects salts shorter than 16 bytes.
+func MnemonicToSeed(mnemonic []string, passphrase string) ([]byte, error) {
+	return pbkdf2.Key(sha512.New, strings.Join(mnemonic, " "), []byte("mnemonic"+passphrase), seedIterations, seedLen)
+}
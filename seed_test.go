@@ -0,0 +1,58 @@
+package recode
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	// official bip39 test vectors, https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+	tests := []struct {
+		mnemonic string
+		seed     string
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			seed, err := MnemonicToSeed(strings.Fields(tt.mnemonic), "TREZOR")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.seed, hex.EncodeToString(seed))
+		})
+	}
+}
+
+func TestMnemonicToSeed_Passphrase(t *testing.T) {
+	mnemonic := strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+
+	seed, err := MnemonicToSeed(mnemonic, "")
+	assert.NoError(t, err)
+	assert.Len(t, seed, 64)
+
+	trezor, err := MnemonicToSeed(mnemonic, "TREZOR")
+	assert.NoError(t, err)
+	assert.NotEqual(t, seed, trezor)
+
+	again, err := MnemonicToSeed(mnemonic, "")
+	assert.NoError(t, err)
+	assert.Equal(t, seed, again)
+}
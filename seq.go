@@ -0,0 +1,50 @@
+package recode
+
+import "iter"
+
+// EncodeSeq returns an iterator over the words Encode(data) returns,
+// without materializing the whole mnemonic, e.g. to send every word
+// over the network as soon as it is produced.
+// The checksum depends on all the data, so it is calculated
+// before the first word is yielded. On error the sequence yields
+// a single ("", err) pair and stops.
+// WithSelfVerify is not applied, there is no mnemonic to verify.
+func (d *Dictionary) EncodeSeq(data []byte) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		data, err := d.config.wrapPayload(data)
+		if err == nil {
+			err = checkPayloadLen(len(data))
+		}
+		if err != nil {
+			yield("", err)
+
+			return
+		}
+
+		words, err := d.appendHeader(nil, data, len(data)*8)
+		if err != nil {
+			yield("", err)
+
+			return
+		}
+
+		// bitsBatchSize bytes are exactly 8 words,
+		// so there is no padding inside the chunks
+		chunkLen := d.bitsBatchSize * 8
+		for {
+			for _, word := range words {
+				if !yield(word, nil) {
+					return
+				}
+			}
+
+			if len(data) == 0 {
+				return
+			}
+
+			chunk := data[:min(chunkLen, len(data))]
+			data = data[len(chunk):]
+			words = d.appendPayload(words[:0], chunk, len(chunk)*8)
+		}
+	}
+}
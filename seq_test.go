@@ -0,0 +1,74 @@
+package recode
+
+import (
+	"errors"
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeSeq(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, fruits, BinaryDictionary, sequentialWords(65536)} {
+		d, err := asDictionary(NewDictionary(words, WithVersion(1)))
+		assert.NoError(t, err)
+
+		for _, l := range []int{0, 1, 7, 8, 16, 33, 100, 257} {
+			data := randomBytes(t, l)
+			want, err := d.Encode(data)
+			assert.NoError(t, err)
+
+			got := []string{}
+			for word, err := range d.EncodeSeq(data) {
+				assert.NoError(t, err)
+				got = append(got, word)
+			}
+			assert.Equal(t, want, got)
+		}
+	}
+}
+
+func TestDic_EncodeSeq_Break(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	got := []string{}
+	for word := range d.EncodeSeq(data) {
+		got = append(got, word)
+		if len(got) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"festival", "among", "way"}, got)
+}
+
+func TestDic_EncodeSeq_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithHash(func() hash.Hash { return failingHash{} })))
+	assert.NoError(t, err)
+
+	calls := 0
+	for word, err := range d.EncodeSeq([]byte("nice!")) {
+		calls++
+		assert.Empty(t, word)
+		assert.EqualError(t, err, "write failed")
+	}
+	assert.Equal(t, 1, calls)
+}
+
+// failingHash fails on any write with data
+type failingHash struct {
+	hash.Hash
+}
+
+func (failingHash) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		return 0, errors.New("write failed")
+	}
+
+	return 0, nil
+}
+
+func (failingHash) Size() int { return 32 }
+
+func (failingHash) Sum(b []byte) []byte { return append(b, make([]byte, 32)...) }
@@ -0,0 +1,355 @@
+package recode
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"hash"
+	"io"
+	"iter"
+)
+
+// mnemonicWriter buffers written data and writes its mnemonic on Close
+type mnemonicWriter struct {
+	d        *Dictionary
+	w        io.Writer
+	buf      []byte
+	closed   bool
+	reported int
+}
+
+// progressInterval is how many bytes are processed between progress calls,
+// see WithProgress
+const progressInterval = 64 << 10
+
+// NewWriter returns a writer which buffers all the written data,
+// and on Close writes space separated mnemonic of it to w,
+// or separated with the separator set by WithSeparator.
+// The checksum depends on all the data, so nothing is written before Close.
+// With WithProgress, the callback gets the number of written bytes,
+// the last call is on successful Close with the full size.
+func (d *Dictionary) NewWriter(w io.Writer) io.WriteCloser {
+	return &mnemonicWriter{d: d, w: w}
+}
+
+func (mw *mnemonicWriter) Write(p []byte) (int, error) {
+	if mw.closed {
+		return 0, errors.New("write to closed writer")
+	}
+
+	mw.buf = append(mw.buf, p...)
+	if mw.d.config.progress != nil && len(mw.buf)-mw.reported >= progressInterval {
+		mw.reported = len(mw.buf)
+		mw.d.config.progress(int64(mw.reported))
+	}
+
+	return len(p), nil
+}
+
+func (mw *mnemonicWriter) Close() error {
+	if mw.closed {
+		return errors.New("writer is already closed")
+	}
+	mw.closed = true
+
+	mnemonic, err := mw.d.encodeVerified(mw.buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(mw.w, mw.d.config.join(mnemonic))
+	if err != nil {
+		return err
+	}
+
+	if mw.d.config.progress != nil {
+		mw.d.config.progress(int64(len(mw.buf)))
+	}
+
+	return nil
+}
+
+// DecodeReader decodes whitespace separated mnemonic read from r,
+// e.g. a text file with one word per line.
+// With WithSeparator words are split by the separator instead.
+// With WithProgress, the callback gets the number of decoded bytes.
+func (d *Dictionary) DecodeReader(r io.Reader) ([]byte, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(d.config.splitFunc())
+
+	sd := d.newStreamDecoder()
+	reported := 0
+	for scanner.Scan() {
+		if err := sd.push(scanner.Text()); err != nil {
+			return nil, err
+		}
+
+		if d.config.progress != nil && len(sd.out)-reported >= progressInterval {
+			reported = len(sd.out)
+			d.config.progress(int64(reported))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := sd.finish(); err != nil {
+		return nil, err
+	}
+
+	if d.config.progress != nil {
+		d.config.progress(int64(len(sd.out)))
+	}
+
+	return d.config.unwrapPayload(sd.out)
+}
+
+// streamDecoder decodes mnemonic words one by one.
+// Every payload word is held back until the next one,
+// so padding of the last word never gets into out.
+type streamDecoder struct {
+	d *Dictionary
+	h hash.Hash
+
+	versionSeen bool
+	firstSeen   bool
+	checksum    string
+	tailLen     int
+	extraWords  []string
+
+	words      int
+	pending    int
+	hasPending bool
+	acc        uint64
+	accLen     int
+
+	// out has decoded bytes, the checksum is verified only by finish,
+	// decoded counts them even if out is drained
+	out     []byte
+	decoded int
+}
+
+func (d *Dictionary) newStreamDecoder() *streamDecoder {
+	return &streamDecoder{
+		d:          d,
+		h:          d.config.hash(),
+		extraWords: make([]string, 0, d.checksumWords-1),
+		out:        []byte{},
+	}
+}
+
+// push decodes the next word of mnemonic
+func (sd *streamDecoder) push(word string) error {
+	d := sd.d
+
+	switch {
+	case d.config.hasVersion && !sd.versionSeen:
+		sd.versionSeen = true
+
+		return d.checkVersionWord(word)
+	case !sd.firstSeen:
+		checksum, tailLen, err := d.parseFirstWord(word)
+		if err != nil {
+			return err
+		}
+		sd.firstSeen, sd.checksum, sd.tailLen = true, checksum, tailLen
+
+		return nil
+	case len(sd.extraWords) < d.checksumWords-1:
+		sd.extraWords = append(sd.extraWords, word)
+
+		return nil
+	}
+
+	sd.words++
+	if sd.words > d.maxPayloadWords() {
+		return ErrPayloadTooLarge
+	}
+	if d.config.maxDecodeWork > 0 && sd.words+d.checksumWords > d.config.maxDecodeWork/d.bitsBatchSize {
+		return ErrDecodeTooExpensive
+	}
+
+	idx, ok := d.lookupIdx(word)
+	if !ok {
+		return errors.New("invalid mnemonic word")
+	}
+
+	var err error
+	if sd.hasPending {
+		// not the last word, so all its bits are payload
+		err = sd.appendBits(sd.pending, -1)
+	}
+	sd.pending, sd.hasPending = idx, true
+
+	return err
+}
+
+// appendBits appends idx bits to the accumulator and moves
+// whole bytes to out, but no more than limit bytes in total if limit >= 0
+func (sd *streamDecoder) appendBits(idx, limit int) error {
+	start := len(sd.out)
+
+	sd.acc = sd.acc<<sd.d.bitsBatchSize | uint64(idx)
+	sd.accLen += sd.d.bitsBatchSize
+	for sd.accLen >= 8 && (limit < 0 || sd.decoded < limit) {
+		sd.accLen -= 8
+		sd.out = append(sd.out, sd.d.config.orderBits(byte(sd.acc>>sd.accLen)))
+		sd.decoded++
+	}
+	sd.acc &= 1<<sd.accLen - 1
+
+	_, err := sd.h.Write(sd.out[start:])
+
+	return err
+}
+
+// finish decodes the last word and verifies the checksum
+func (sd *streamDecoder) finish() error {
+	d := sd.d
+
+	if !sd.firstSeen {
+		return errors.New("empty mnemonic")
+	}
+	if len(sd.extraWords) < d.checksumWords-1 {
+		return errors.New("mnemonic is too short for the checksum")
+	}
+
+	bitsLen, err := d.payloadBits(sd.words, sd.tailLen)
+	if err != nil {
+		return err
+	}
+	if err := d.checkTrailingBits(bitsLen); err != nil {
+		return err
+	}
+
+	if sd.hasPending {
+		// drop tail padding
+		if err := sd.appendBits(sd.pending, bitsLen/8); err != nil {
+			return err
+		}
+		sd.hasPending = false
+	}
+
+	sum, err := d.sumChecksum(sd.h, sd.decoded*8)
+	if err != nil {
+		return err
+	}
+
+	decodedChecksum := idxToBitString(d.checksumFromSum(sum), d.checksumLen)
+	extraValid, err := d.extraChecksumValid(sum, sd.extraWords)
+	if err != nil {
+		return err
+	}
+
+	if !d.config.withoutChecksum && (!checksumEqual(sd.checksum, decodedChecksum) || !extraValid) {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+// wordsReader is returned by DecodeReaderFromWords
+type wordsReader struct {
+	sd   *streamDecoder
+	next func() (string, bool)
+	stop func()
+	read int
+	err  error
+}
+
+// DecodeReaderFromWords returns a reader of bytes decoded from words,
+// e.g. from network or database rows, without collecting the whole mnemonic.
+// Bytes are returned as soon as they are decoded, but the checksum
+// covers all the data, so it is verified only at the end:
+// the final Read returns ErrInvalidChecksum instead of io.EOF on mismatch.
+// Do not trust the data until io.EOF is returned.
+// The reader also implements io.Closer to stop the iteration early.
+// With WithCompression or WithPayloadPrefix bytes are returned only after
+// all words are read, the payload is unwrapped after the checksum is verified.
+func (d *Dictionary) DecodeReaderFromWords(words iter.Seq[string]) io.Reader {
+	next, stop := iter.Pull(words)
+	wr := &wordsReader{sd: d.newStreamDecoder(), next: next, stop: stop}
+
+	if d.config.wrapsPayload() {
+		return &unwrappingReader{raw: wr, c: d.config}
+	}
+
+	return wr
+}
+
+// unwrappingReader reads the whole payload from raw on the first Read
+// and returns its unwrapped data, see WithCompression and WithPayloadPrefix
+type unwrappingReader struct {
+	raw  *wordsReader
+	c    config
+	data *bytes.Reader
+	err  error
+}
+
+func (ir *unwrappingReader) Read(p []byte) (int, error) {
+	if ir.data == nil && ir.err == nil {
+		var data []byte
+		payload, err := io.ReadAll(ir.raw)
+		if err == nil {
+			data, err = ir.c.unwrapPayload(payload)
+		}
+
+		ir.data, ir.err = bytes.NewReader(data), err
+	}
+
+	if ir.err != nil {
+		return 0, ir.err
+	}
+
+	return ir.data.Read(p)
+}
+
+func (ir *unwrappingReader) Close() error {
+	if ir.err == nil {
+		ir.err = errors.New("read from closed reader")
+	}
+
+	return ir.raw.Close()
+}
+
+func (wr *wordsReader) Read(p []byte) (int, error) {
+	for wr.read == len(wr.sd.out) && wr.err == nil {
+		// decoded bytes are already returned, free them
+		wr.sd.out, wr.read = wr.sd.out[:0], 0
+
+		word, ok := wr.next()
+		if !ok {
+			wr.stop()
+			wr.err = wr.sd.finish()
+			if wr.err == nil {
+				wr.err = io.EOF
+			}
+
+			break
+		}
+
+		if err := wr.sd.push(word); err != nil {
+			wr.stop()
+			wr.err = err
+		}
+	}
+
+	if wr.read < len(wr.sd.out) {
+		n := copy(p, wr.sd.out[wr.read:])
+		wr.read += n
+
+		return n, nil
+	}
+
+	return 0, wr.err
+}
+
+func (wr *wordsReader) Close() error {
+	wr.stop()
+	wr.sd.out, wr.read = wr.sd.out[:0], 0
+	if wr.err == nil {
+		wr.err = errors.New("read from closed reader")
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package recode
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_NewWriter(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	w := d.NewWriter(&out)
+
+	for _, part := range [][]byte{{7}, {}, {255, 1}, {255, 40, 128, 42}, {42}} {
+		n, err := w.Write(part)
+		assert.NoError(t, err)
+		assert.Equal(t, len(part), n)
+		// nothing is written before close
+		assert.Equal(t, 0, out.Len())
+	}
+
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "festival among way lemon extra actor betray", out.String())
+
+	_, err = w.Write([]byte{1})
+	assert.Error(t, err)
+	assert.Error(t, w.Close())
+}
+
+func TestDic_NewWriter_Empty(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}))
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	w := d.NewWriter(&out)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "fizz", out.String())
+}
+
+func TestDic_DecodeReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{"line per word", "festival\namong\nway\nlemon\nextra\nactor\nbetray\n", []byte{7, 255, 1, 255, 40, 128, 42, 42}, false},
+		{"spaces", "  festival among\tway lemon\n\nextra actor betray", []byte{7, 255, 1, 255, 40, 128, 42, 42}, false},
+		{"empty", "\n \n", nil, true},
+		{"invalid checksum", "fire among way lemon extra actor betray", nil, true},
+		{"invalid word", "festival among WTF lemon extra actor betray", nil, true},
+		{"invalid first word", "WTF among way lemon extra actor betray", nil, true},
+		{"invalid tail", Bip39Dictionary[1], nil, true},
+	}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.DecodeReader(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDic_DecodeReader_SameAsDecode(t *testing.T) {
+	for _, words := range [][]string{{"0", "1"}, {"foo", "bar", "fizz", "buzz"}, fruits, Bip39Dictionary, sequentialWords(65536)} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+
+		for l := 0; l < 40; l++ {
+			data := randomBytes(t, l)
+
+			mnemonic, err := d.EncodeString(data)
+			assert.NoError(t, err)
+
+			got, err := d.DecodeReader(strings.NewReader(mnemonic))
+			assert.NoError(t, err)
+			assert.Equal(t, data, got)
+		}
+	}
+}
+
+func TestDic_DecodeReader_MaxDecodeWork(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithMaxDecodeWork(7*11)))
+	assert.NoError(t, err)
+
+	_, err = d.DecodeReader(strings.NewReader("festival among way lemon extra actor betray"))
+	assert.NoError(t, err)
+
+	_, err = d.DecodeReader(strings.NewReader("festival among way lemon extra actor betray betray"))
+	assert.ErrorIs(t, err, ErrDecodeTooExpensive)
+}
+
+func TestWithProgress(t *testing.T) {
+	var calls []int64
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithProgress(func(n int64) {
+		calls = append(calls, n)
+	})))
+	assert.NoError(t, err)
+
+	data := randomBytes(t, 3*progressInterval+100)
+
+	var out bytes.Buffer
+	w := d.NewWriter(&out)
+	for chunk := range slices.Chunk(data, 1000) {
+		_, err = w.Write(chunk)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	// reported on writes, after every 64KiB
+	assert.Equal(t, []int64{66000, 132000, int64(len(data))}, calls)
+
+	calls = nil
+	decoded, err := d.DecodeReader(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	assert.Len(t, calls, 4)
+	assert.True(t, slices.IsSorted(calls))
+	assert.Equal(t, int64(len(data)), calls[len(calls)-1])
+}
+
+// chunkedWords yields words by chunks of n, as a paged network or database source
+func chunkedWords(words []string, n int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for chunk := range slices.Chunk(words, n) {
+			for _, word := range slices.Clone(chunk) {
+				if !yield(word) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestDic_DecodeReaderFromWords(t *testing.T) {
+	for _, words := range [][]string{{"0", "1"}, {"foo", "bar", "fizz", "buzz"}, fruits, Bip39Dictionary, sequentialWords(65536)} {
+		for _, opts := range [][]Option{nil, {WithVersion(1)}, {WithChecksumWords(2)}} {
+			d, err := asDictionary(NewDictionary(words, opts...))
+			assert.NoError(t, err)
+
+			for l := 0; l < 40; l++ {
+				data := randomBytes(t, l)
+
+				mnemonic, err := d.Encode(data)
+				assert.NoError(t, err)
+
+				r := d.DecodeReaderFromWords(chunkedWords(mnemonic, 3))
+				got, err := io.ReadAll(iotest.OneByteReader(r))
+				assert.NoError(t, err)
+				assert.Equal(t, data, got)
+			}
+		}
+	}
+}
+
+func TestDic_DecodeReaderFromWords_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	// bytes are returned before the checksum is verified
+	r := d.DecodeReaderFromWords(slices.Values(strings.Fields("fire among way lemon extra actor betray")))
+	got, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	assert.Equal(t, []byte{7, 255, 1, 255, 40, 128, 42, 42}, got)
+
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	r = d.DecodeReaderFromWords(slices.Values(strings.Fields("festival among WTF lemon extra actor betray")))
+	_, err = io.ReadAll(r)
+	assert.EqualError(t, err, "invalid mnemonic word")
+
+	r = d.DecodeReaderFromWords(slices.Values([]string{}))
+	_, err = io.ReadAll(r)
+	assert.EqualError(t, err, "empty mnemonic")
+}
+
+func TestDic_DecodeReaderFromWords_Close(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode(randomBytes(t, 100))
+	assert.NoError(t, err)
+
+	yielded := 0
+	words := func(yield func(string) bool) {
+		for _, word := range mnemonic {
+			yielded++
+			if !yield(word) {
+				return
+			}
+		}
+	}
+
+	r := d.DecodeReaderFromWords(words)
+	_, err = r.Read(make([]byte, 1))
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.(io.Closer).Close())
+	assert.Less(t, yielded, len(mnemonic))
+
+	_, err = r.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package recode
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// EncodeString encodes data into a space separated mnemonic phrase,
+// or separated with the separator set by WithSeparator.
+func (d *Dictionary) EncodeString(data []byte) (string, error) {
+	mnemonic, err := d.encodeVerified(data)
+	if err != nil {
+		return "", err
+	}
+
+	return d.config.join(mnemonic), nil
+}
+
+// DecodeString decodes a mnemonic phrase.
+// Words could be separated by any amount of unicode whitespace,
+// leading and trailing whitespace is ignored.
+// With WithSeparator words are split by the separator instead,
+// whitespace around every word is ignored.
+func (d *Dictionary) DecodeString(phrase string) ([]byte, error) {
+	return d.decode(d.config.split(phrase))
+}
+
+// join joins mnemonic words with the separator, space by default
+func (c config) join(mnemonic []string) string {
+	if c.separator == "" {
+		return strings.Join(mnemonic, " ")
+	}
+
+	return strings.Join(mnemonic, c.separator)
+}
+
+// split splits phrase into words, see DecodeString
+func (c config) split(phrase string) []string {
+	if c.separator == "" {
+		return strings.Fields(phrase)
+	}
+
+	words := []string{}
+	for word := range strings.SplitSeq(phrase, c.separator) {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// splitFunc returns a bufio.SplitFunc for words of a mnemonic, see split
+func (c config) splitFunc() bufio.SplitFunc {
+	if c.separator == "" {
+		return bufio.ScanWords
+	}
+
+	sep := []byte(c.separator)
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		end, advance := bytes.Index(data, sep), 0
+		switch {
+		case end >= 0:
+			advance = end + len(sep)
+		case atEOF:
+			end, advance = len(data), len(data)
+		default:
+			// request more data
+			return 0, nil, nil
+		}
+
+		word := bytes.TrimSpace(data[:end])
+		if len(word) == 0 {
+			// skip empty words, nil token makes scanner continue
+			return advance, nil, nil
+		}
+
+		return advance, word, nil
+	}
+}
@@ -0,0 +1,76 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeString(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	got, err := d.EncodeString([]byte{7, 255, 1, 255, 40, 128, 42, 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "festival among way lemon extra actor betray", got)
+}
+
+func TestDic_DecodeString(t *testing.T) {
+	tests := []struct {
+		name    string
+		phrase  string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			"clean",
+			"festival among way lemon extra actor betray",
+			[]byte{7, 255, 1, 255, 40, 128, 42, 42},
+			false,
+		},
+		{
+			"mixed separators",
+			"  festival\n among\tway \r\nlemon extra　actor  betray ",
+			[]byte{7, 255, 1, 255, 40, 128, 42, 42},
+			false,
+		},
+		{
+			"one word per line",
+			"festival\namong\nway\nlemon\nextra\nactor\nbetray\n",
+			[]byte{7, 255, 1, 255, 40, 128, 42, 42},
+			false,
+		},
+		{
+			"empty",
+			" \t\n",
+			nil,
+			true,
+		},
+		{
+			"split word",
+			"festival among way lem on extra actor betray",
+			nil,
+			true,
+		},
+		{
+			"glued words",
+			"festival among waylemon extra actor betray",
+			nil,
+			true,
+		},
+	}
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.DecodeString(tt.phrase)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
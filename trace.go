@@ -0,0 +1,33 @@
+package recode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EncodeTrace is a diagnostic API, it encodes data as Encode does and
+// also returns the bit string behind the mnemonic, every word as
+// bitsPerWord '0' and '1' characters: optional version word,
+// the first word with the checksum and the tail length, optional extra
+// checksum words and the payload with the last word padded with ones.
+// Use it to port the format to another language or to debug a mismatch,
+// not in production code, the bit string is 8 times bigger than data.
+func (d *Dictionary) EncodeTrace(data []byte) ([]string, string, error) {
+	mnemonic, err := d.encodeVerified(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var bits strings.Builder
+	bits.Grow(len(mnemonic) * d.bitsBatchSize)
+	for i, word := range mnemonic {
+		// words are keyed by the normalized form, e.g. with WithCaseInsensitive
+		wordBits, ok := d.lookup(word)
+		if !ok {
+			return nil, "", fmt.Errorf("word %d %q: invalid mnemonic words", i, word)
+		}
+		bits.WriteString(wordBits)
+	}
+
+	return mnemonic, bits.String(), nil
+}
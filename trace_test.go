@@ -0,0 +1,70 @@
+package recode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeTrace(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte("nice!")
+	mnemonic, bits, err := d.EncodeTrace(data)
+	assert.NoError(t, err)
+
+	want, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, want, mnemonic)
+	assert.Len(t, bits, len(mnemonic)*11)
+
+	sum, err := d.LastChecksum(data)
+	assert.NoError(t, err)
+
+	var dataBits strings.Builder
+	for _, b := range data {
+		fmt.Fprintf(&dataBits, "%08b", b)
+	}
+
+	// 40 bits = 3 words and 7 bits tail
+	assert.Equal(t, fmt.Sprintf("%08b", sum[0])[:7], bits[:7])
+	assert.Equal(t, "0111", bits[7:11])
+	assert.Equal(t, dataBits.String(), bits[11:51])
+	assert.Equal(t, "1111", bits[51:])
+}
+
+func TestDic_EncodeTrace_Version(t *testing.T) {
+	d, err := asDictionary(NewHexDictionary(WithVersion(10), WithChecksumWords(2)))
+	assert.NoError(t, err)
+
+	mnemonic, bits, err := d.EncodeTrace([]byte{0x42})
+	assert.NoError(t, err)
+	assert.Len(t, mnemonic, 5)
+	assert.Equal(t, "1010", bits[:4])
+	assert.Equal(t, "01000010", bits[12:])
+}
+
+func TestDic_EncodeTrace_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithWholeBytesOnly()))
+	assert.NoError(t, err)
+
+	_, _, err = d.EncodeTrace(make([]byte, 7))
+	assert.Error(t, err)
+}
+
+func TestDic_EncodeTrace_CaseInsensitive(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"A", "B", "C", "D"}, WithCaseInsensitive()))
+	assert.NoError(t, err)
+
+	mnemonic, bits, err := d.EncodeTrace([]byte{0x42})
+	assert.NoError(t, err)
+	assert.Len(t, bits, len(mnemonic)*2)
+	assert.Equal(t, "01000010", bits[len(bits)-8:])
+
+	want, err := d.Encode([]byte{0x42})
+	assert.NoError(t, err)
+	assert.Equal(t, want, mnemonic)
+}
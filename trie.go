@@ -0,0 +1,199 @@
+package recode
+
+import (
+	"fmt"
+	"slices"
+)
+
+// trie is a byte prefix tree of normalized dictionary words
+type trie struct {
+	children map[byte]*trie
+	// word is the dictionary word ending at this node, if any,
+	// and idx is its index in the dictionary
+	word string
+	idx  int
+	end  bool
+}
+
+func newTrie() *trie {
+	return &trie{children: map[byte]*trie{}}
+}
+
+func (t *trie) insert(key, word string, idx int) {
+	node := t
+	for i := 0; i < len(key); i++ {
+		next, ok := node.children[key[i]]
+		if !ok {
+			next = newTrie()
+			node.children[key[i]] = next
+		}
+		node = next
+	}
+
+	node.word = word
+	node.idx = idx
+	node.end = true
+}
+
+// find returns the node of prefix, or nil if no word starts with it
+func (t *trie) find(prefix string) *trie {
+	node := t
+	for i := 0; i < len(prefix) && node != nil; i++ {
+		node = node.children[prefix[i]]
+	}
+
+	return node
+}
+
+// appendIdx appends indexes of all the words in the subtree to dst
+func (t *trie) appendIdx(dst []int) []int {
+	if t.end {
+		dst = append(dst, t.idx)
+	}
+	for _, child := range t.children {
+		dst = child.appendIdx(dst)
+	}
+
+	return dst
+}
+
+// longestMatch returns the longest word which is a prefix of s and its length in s
+func (t *trie) longestMatch(s string) (string, int, bool) {
+	var (
+		word  string
+		l     int
+		found bool
+	)
+
+	node := t
+	for i := 0; i < len(s); i++ {
+		next, ok := node.children[s[i]]
+		if !ok {
+			break
+		}
+		node = next
+
+		if node.end {
+			word, l, found = node.word, i+1, true
+		}
+	}
+
+	return word, l, found
+}
+
+// segment splits s into words, preferring longer words at every position,
+// but unlike longestMatch alone it falls back to shorter words, if
+// the longest one leads to a dead end. It returns lengths of the words.
+func (t *trie) segment(s string) ([]int, bool) {
+	// next[pos] is the length of the longest word at pos,
+	// after which the rest of s could be split, 0 if there is none
+	next := make([]int, len(s)+1)
+	for pos := len(s) - 1; pos >= 0; pos-- {
+		node := t
+		for i := pos; i < len(s); i++ {
+			node = node.children[s[i]]
+			if node == nil {
+				break
+			}
+
+			if node.end && (i+1 == len(s) || next[i+1] > 0) {
+				next[pos] = i + 1 - pos
+			}
+		}
+	}
+
+	if len(s) > 0 && next[0] == 0 {
+		return nil, false
+	}
+
+	lens := []int{}
+	for pos := 0; pos < len(s); pos += next[pos] {
+		lens = append(lens, next[pos])
+	}
+
+	return lens, true
+}
+
+// Tokenize splits a mnemonic concatenated without separators into words,
+// using the longest match at every position.
+// If the longest match leads to a dead end, shorter words are tried,
+// e.g. for Japanese word lists, where words share prefixes.
+// Prefix free dictionaries, like the Chinese bip39 lists with single
+// character words, never need it, see IsPrefixFree.
+// Otherwise a string could have several splits and the one
+// with longer words first is returned.
+// The prefix tree is built once on the first call.
+func (d *Dictionary) Tokenize(s string) ([]string, error) {
+	s = d.config.normalize(s)
+	t := d.prefixTree()
+
+	words := []string{}
+	for pos := 0; pos < len(s); {
+		word, l, ok := t.longestMatch(s[pos:])
+		if !ok {
+			return d.tokenizeBacktrack(s, pos)
+		}
+
+		words = append(words, word)
+		pos += l
+	}
+
+	return words, nil
+}
+
+// tokenizeBacktrack splits s with backtracking,
+// stuck is the position where the longest matches failed
+func (d *Dictionary) tokenizeBacktrack(s string, stuck int) ([]string, error) {
+	t := d.prefixTree()
+
+	lens, ok := t.segment(s)
+	if !ok {
+		return nil, fmt.Errorf("unknown word at %d: %q", stuck, s[stuck:])
+	}
+
+	words := make([]string, 0, len(lens))
+	pos := 0
+	for _, l := range lens {
+		words = append(words, t.find(s[pos:pos+l]).word)
+		pos += l
+	}
+
+	return words, nil
+}
+
+// Autocomplete returns up to n dictionary words starting with prefix,
+// in dictionary order, e.g. to suggest words while a user types them.
+// For bip39 first 4 letters of a word are always enough to leave only it.
+// Prefix is normalized as words for Decode, see WithCaseInsensitive.
+// The prefix tree is built once on the first call.
+func (d *Dictionary) Autocomplete(prefix string, n int) []string {
+	words := []string{}
+	if n <= 0 {
+		return words
+	}
+
+	node := d.prefixTree().find(d.config.normalize(prefix))
+	if node == nil {
+		return words
+	}
+
+	idxs := node.appendIdx(nil)
+	slices.Sort(idxs)
+	for _, idx := range idxs[:min(n, len(idxs))] {
+		words = append(words, d.words[idx])
+	}
+
+	return words
+}
+
+// prefixTree returns the prefix tree of normalized words, building it on the first call
+func (d *Dictionary) prefixTree() *trie {
+	d.trieOnce.Do(func() {
+		d.trie = newTrie()
+		for i, word := range d.words {
+			d.trie.insert(d.config.normalize(word), word, i)
+		}
+	})
+
+	return d.trie
+}
@@ -0,0 +1,137 @@
+package recode
+
+import (
+	"hash/crc32"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_Tokenize(t *testing.T) {
+	d, err := asDictionary(NewDictionary(fruits))
+	assert.NoError(t, err)
+
+	entropy := []byte{138, 252, 148, 132, 177, 104, 151, 15, 191, 157, 140, 195, 148, 64, 81, 116}
+
+	salat, err := d.Encode(entropy)
+	assert.NoError(t, err)
+
+	got, err := d.Tokenize(strings.Join(salat, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, salat, got)
+
+	decoded, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, entropy, decoded)
+
+	got, err = d.Tokenize("")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = d.Tokenize("🍒🥥x🍒")
+	assert.Error(t, err)
+
+	// partial emoji
+	_, err = d.Tokenize("🍒🥥"[:6])
+	assert.Error(t, err)
+}
+
+func TestDic_Tokenize_LongestMatch(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"a", "ab", "abc", "b"}, WithCaseInsensitive()))
+	assert.NoError(t, err)
+
+	got, err := d.Tokenize("abcABab")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abc", "ab", "ab"}, got)
+}
+
+func TestDic_Autocomplete(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithCaseInsensitive()))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"abandon", "ability", "able"}, d.Autocomplete("ab", 3))
+	assert.Equal(t, []string{"zebra", "zero", "zone", "zoo"}, d.Autocomplete("Z", 10))
+	assert.Equal(t, []string{"act", "action", "actor", "actress", "actual"}, d.Autocomplete("act", 10))
+	assert.Equal(t, []string{"abandon", "ability"}, d.Autocomplete("", 2))
+	assert.Empty(t, d.Autocomplete("xyz", 10))
+	assert.Empty(t, d.Autocomplete("ab", 0))
+
+	// 4 letters are enough for bip39, shorter words are complete
+	for _, word := range Bip39Dictionary {
+		if len(word) < 4 {
+			assert.Equal(t, word, d.Autocomplete(word, 10)[0])
+
+			continue
+		}
+
+		assert.Equal(t, []string{word}, d.Autocomplete(word[:4], 10), word)
+	}
+}
+
+func TestDic_Autocomplete_Emoji(t *testing.T) {
+	d, err := asDictionary(NewDictionary(fruits))
+	assert.NoError(t, err)
+
+	// 🌶️ is a pepper with a variation selector
+	assert.Equal(t, []string{"🌶️"}, d.Autocomplete("🌶", 10))
+	// partial emoji
+	assert.Len(t, d.Autocomplete("🍇"[:3], 100), 14)
+}
+
+func TestDic_Tokenize_Backtrack(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"a", "ab", "bcd", "x"}))
+	assert.NoError(t, err)
+
+	// the longest match "ab" leaves "cd" unknown
+	got, err := d.Tokenize("abcdabx")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "bcd", "ab", "x"}, got)
+
+	_, err = d.Tokenize("abcx")
+	assert.EqualError(t, err, `unknown word at 2: "cx"`)
+}
+
+// chineseWords returns the official Chinese simplified bip39 word list,
+// https://raw.githubusercontent.com/bitcoin/bips/master/bip-0039/chinese_simplified.txt
+func chineseWords(t testing.TB) []string {
+	raw, err := os.ReadFile("testdata/chinese_simplified.txt")
+	assert.NoError(t, err)
+	// crc32 of the file in the bips repository
+	assert.Equal(t, uint32(0xe3721bbf), crc32.ChecksumIEEE(raw))
+
+	return strings.Split(strings.TrimSpace(string(raw)), "\n")
+}
+
+func TestDic_Tokenize_CJK(t *testing.T) {
+	d, err := asDictionary(NewDictionary(chineseWords(t)))
+	assert.NoError(t, err)
+	assert.True(t, d.IsPrefixFree())
+
+	entropy := []byte{138, 252, 148, 132, 177, 104, 151, 15, 191, 157, 140, 195, 148, 64, 81, 116}
+	mnemonic, err := d.Encode(entropy)
+	assert.NoError(t, err)
+
+	got, err := d.Tokenize(strings.Join(mnemonic, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, got)
+
+	decoded, err := d.Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, entropy, decoded)
+
+	// bip39 mnemonics of the official list, written without spaces
+	for _, phrase := range []string{
+		// 16 zero bytes
+		"的 的 的 的 的 的 的 的 的 的 的 在",
+		// 16 bytes of 0x7f
+		"枪 疫 霉 尝 俩 闹 饿 贤 枪 疫 霉 卿",
+		// 32 bytes of 0x80
+		"壤 对 据 人 三 谈 我 表 壤 对 据 人 三 谈 我 表 壤 对 据 人 三 谈 我 五",
+	} {
+		got, err := d.Tokenize(strings.ReplaceAll(phrase, " ", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Fields(phrase), got)
+	}
+}
@@ -0,0 +1,88 @@
+package recode
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// uniqueNonceLen is the length of random nonce prepended to the payload by EncodeUnique
+const uniqueNonceLen = 2
+
+// EncodeUnique encodes data into a mnemonic without repeated words.
+// It prepends a random nonce read from rng to the data masked with
+// a nonce derived keystream, and tries new nonces until mnemonic
+// has no duplicates or maxAttempts is reached.
+// Use DecodeUnique to get data back.
+func (d *Dictionary) EncodeUnique(data []byte, rng io.Reader, maxAttempts int) ([]string, error) {
+	payload := make([]byte, uniqueNonceLen+len(data))
+
+	wordsCount := d.EncodedLen(len(payload))
+	if wordsCount > len(d.words) {
+		return nil, fmt.Errorf("%w: %d words mnemonic with %d words dictionary", ErrNotUnique, wordsCount, len(d.words))
+	}
+
+	for range maxAttempts {
+		if _, err := io.ReadFull(rng, payload[:uniqueNonceLen]); err != nil {
+			return nil, err
+		}
+		maskWithNonce(payload[uniqueNonceLen:], data, payload[:uniqueNonceLen])
+
+		mnemonic, err := d.encodeVerified(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasDuplicates(mnemonic) {
+			return mnemonic, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: after %d attempts", ErrNotUnique, maxAttempts)
+}
+
+// DecodeUnique decodes mnemonic created by EncodeUnique.
+func (d *Dictionary) DecodeUnique(mnemonic []string) ([]byte, error) {
+	payload, err := d.decode(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) < uniqueNonceLen {
+		return nil, errors.New("missing nonce")
+	}
+
+	data := payload[uniqueNonceLen:]
+	maskWithNonce(data, data, payload[:uniqueNonceLen])
+
+	return data, nil
+}
+
+// maskWithNonce xors src with sha256(nonce || counter) keystream into dst
+func maskWithNonce(dst, src, nonce []byte) {
+	var block [sha256.Size]byte
+	counter := make([]byte, len(nonce)+4)
+	copy(counter, nonce)
+
+	for i := range src {
+		if i%sha256.Size == 0 {
+			binary.BigEndian.PutUint32(counter[len(nonce):], uint32(i/sha256.Size))
+			block = sha256.Sum256(counter)
+		}
+		dst[i] = src[i] ^ block[i%sha256.Size]
+	}
+}
+
+func hasDuplicates(mnemonic []string) bool {
+	seen := make(map[string]bool, len(mnemonic))
+	for _, word := range mnemonic {
+		if seen[word] {
+			return true
+		}
+		seen[word] = true
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package recode
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeUnique(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+
+	plain, err := d.Encode(data)
+	assert.NoError(t, err)
+	assert.True(t, hasDuplicates(plain))
+
+	mnemonic, err := d.EncodeUnique(data, rand.Reader, 100)
+	assert.NoError(t, err)
+	assert.False(t, hasDuplicates(mnemonic))
+
+	decoded, err := d.DecodeUnique(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestDic_EncodeUnique_Impossible(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"foo", "bar", "fizz", "buzz"}))
+	assert.NoError(t, err)
+
+	_, err = d.EncodeUnique([]byte("nice!"), rand.Reader, 100)
+	assert.ErrorIs(t, err, ErrNotUnique)
+
+	// no attempts left
+	d, err = asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	_, err = d.EncodeUnique(make([]byte, 8), rand.Reader, 0)
+	assert.ErrorIs(t, err, ErrNotUnique)
+
+	// rng errors are returned
+	_, err = d.EncodeUnique(make([]byte, 8), bytes.NewReader(nil), 10)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotUnique)
+}
+
+func TestDic_EncodeUnique_HeaderWords(t *testing.T) {
+	// 14 payload words fit, but not with 3 header words
+	d, err := asDictionary(NewDictionary(sequentialWords(16), WithVersion(0), WithChecksumWords(2)))
+	assert.NoError(t, err)
+
+	_, err = d.EncodeUnique(make([]byte, 5), rand.Reader, 100)
+	assert.EqualError(t, err, "unable to encode with unique words: 17 words mnemonic with 16 words dictionary")
+}
+
+func TestDic_DecodeUnique_NoNonce(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	mnemonic, err := d.Encode([]byte{1})
+	assert.NoError(t, err)
+
+	_, err = d.DecodeUnique(mnemonic)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package recode
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Vector is a test vector of the mnemonic format
+// for implementations in other languages, see GenerateVectors.
+type Vector struct {
+	Name string `json:"name"`
+	// Dictionary is the name of the word list: bip39, slip39, binary or hex
+	Dictionary  string `json:"dictionary"`
+	BitsPerWord int    `json:"bitsPerWord"`
+	// Input is hex encoded data
+	Input    string   `json:"input"`
+	Mnemonic []string `json:"mnemonic"`
+	// ChecksumBits and TailLen are the parts of the first word, see InspectFirstWord
+	ChecksumBits string `json:"checksumBits"`
+	TailLen      int    `json:"tailLen"`
+	// Bits is the bit string behind the mnemonic, see EncodeTrace
+	Bits string `json:"bits"`
+}
+
+// vectorDictionary is a built-in word list for GenerateVectors
+type vectorDictionary struct {
+	name  string
+	words []string
+}
+
+var builtinVectorDictionaries = []vectorDictionary{
+	{"bip39", Bip39Dictionary},
+	{"slip39", Slip39Dictionary},
+	{"binary", BinaryDictionary},
+	{"hex", HexDictionary},
+}
+
+// GenerateVectors writes JSON test vectors of the built-in dictionaries
+// with default options to w, so ports of the format to other languages
+// could check their compatibility. Vectors cover empty data,
+// data ending on a word boundary, the longest tail possible for
+// whole bytes, data of all ones and the common entropy sizes.
+// The output is deterministic, it is kept in testdata/interop.json.
+func GenerateVectors(w io.Writer) error {
+	vectors, err := generateVectors()
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(vectors)
+}
+
+func generateVectors() ([]Vector, error) {
+	vectors := []Vector{}
+	for _, vd := range builtinVectorDictionaries {
+		d, err := newDictionary(vd.words)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range vectorCases(d.bitsBatchSize) {
+			mnemonic, bits, err := d.EncodeTrace(c.data)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", vd.name, c.name, err)
+			}
+
+			checksumBits, tailLen, err := d.InspectFirstWord(mnemonic[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", vd.name, c.name, err)
+			}
+
+			vectors = append(vectors, Vector{
+				Name:         vd.name + " " + c.name,
+				Dictionary:   vd.name,
+				BitsPerWord:  d.bitsBatchSize,
+				Input:        hex.EncodeToString(c.data),
+				Mnemonic:     mnemonic,
+				ChecksumBits: checksumBits,
+				TailLen:      tailLen,
+				Bits:         bits,
+			})
+		}
+	}
+
+	return vectors, nil
+}
+
+type vectorCase struct {
+	name string
+	data []byte
+}
+
+// vectorCases returns inputs for a dictionary of bitsPerWord bits per word
+func vectorCases(bitsPerWord int) []vectorCase {
+	cases := []vectorCase{
+		{"empty", []byte{}},
+		{"one byte", vectorData(1)},
+		// 2*bitsPerWord bytes are exactly 16 words, so the tail is 0
+		{"word boundary", vectorData(2 * bitsPerWord)},
+	}
+
+	// the longest tail of whole bytes, bitsPerWord-1 only if it is reachable
+	maxTail, maxTailLen := 0, 0
+	for n := 1; n <= bitsPerWord; n++ {
+		if tail := n * 8 % bitsPerWord; tail > maxTail {
+			maxTail, maxTailLen = tail, n
+		}
+	}
+	// one byte is already there
+	if maxTailLen > 1 {
+		cases = append(cases, vectorCase{fmt.Sprintf("tail %d", maxTail), vectorData(maxTailLen)})
+	}
+
+	return append(cases,
+		vectorCase{"all ones", []byte{0xff, 0xff, 0xff, 0xff, 0xff}},
+		vectorCase{"128 bit", vectorData(16)},
+		vectorCase{"256 bit", vectorData(32)},
+	)
+}
+
+// vectorData returns n bytes of a fixed pattern
+func vectorData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i*0x9d + 0x3b)
+	}
+
+	return data
+}
@@ -0,0 +1,108 @@
+package recode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testVector struct {
+	Name       string   `json:"name"`
+	Dictionary string   `json:"dictionary,omitempty"`
+	Words      []string `json:"words,omitempty"`
+	Input      string   `json:"input"`
+	Mnemonic   []string `json:"mnemonic"`
+}
+
+var vectorDictionaries = map[string][]string{
+	"bip39":  Bip39Dictionary,
+	"slip39": Slip39Dictionary,
+	"fruits": fruits,
+	"binary": BinaryDictionary,
+	"hex":    HexDictionary,
+}
+
+// TestVectors locks the wire format, any change of the encoding should fail it
+func TestVectors(t *testing.T) {
+	raw, err := os.ReadFile("testdata/vectors.json")
+	assert.NoError(t, err)
+
+	var vectors []testVector
+	assert.NoError(t, json.Unmarshal(raw, &vectors))
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			words := v.Words
+			if words == nil {
+				words = vectorDictionaries[v.Dictionary]
+			}
+			assert.NotNil(t, words, "unknown dictionary %q", v.Dictionary)
+
+			d, err := asDictionary(NewDictionary(words))
+			assert.NoError(t, err)
+
+			input, err := hex.DecodeString(v.Input)
+			assert.NoError(t, err)
+
+			got, err := d.Encode(input)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Mnemonic, got)
+
+			decoded, err := d.Decode(v.Mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, input, decoded)
+
+			bits, err := d.EntropyBits(v.Mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, len(input)*8, bits)
+		})
+	}
+}
+
+// TestGenerateVectors checks testdata/interop.json,
+// regenerate it with UPDATE_VECTORS=1 go test -run TestGenerateVectors
+func TestGenerateVectors(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, GenerateVectors(&buf))
+
+	if os.Getenv("UPDATE_VECTORS") != "" {
+		assert.NoError(t, os.WriteFile("testdata/interop.json", buf.Bytes(), 0o644))
+	}
+
+	golden, err := os.ReadFile("testdata/interop.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), buf.String())
+
+	var vectors []Vector
+	assert.NoError(t, json.Unmarshal(golden, &vectors))
+
+	names := map[string]bool{}
+	for _, v := range vectors {
+		assert.False(t, names[v.Name], "duplicate vector %q", v.Name)
+		names[v.Name] = true
+
+		d, err := NewDictionary(vectorDictionaries[v.Dictionary])
+		assert.NoError(t, err)
+
+		input, err := hex.DecodeString(v.Input)
+		assert.NoError(t, err)
+
+		decoded, err := d.Decode(v.Mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, input, decoded)
+
+		// bits are the words in order, the first one is checksum and tail
+		assert.Len(t, v.Bits, len(v.Mnemonic)*v.BitsPerWord)
+		assert.Equal(t, v.ChecksumBits, v.Bits[:len(v.ChecksumBits)])
+		assert.Equal(t, len(input)*8%v.BitsPerWord, v.TailLen)
+	}
+
+	for _, name := range []string{"bip39 empty", "bip39 word boundary", "bip39 tail 10", "hex word boundary"} {
+		assert.True(t, names[name], name)
+	}
+}
@@ -0,0 +1,60 @@
+package recode
+
+import "fmt"
+
+// Word is a mnemonic word with its position, e.g. for UIs
+// which number words and highlight the checksum.
+type Word struct {
+	// Index is the 0-based position of the word in the mnemonic
+	Index int
+	Text  string
+	// IsChecksum is true for the first word and extra checksum words,
+	// see WithChecksumWords. The version word is not a checksum word.
+	IsChecksum bool
+}
+
+// EncodeWords encodes data as Encode does and returns structured words.
+func (d *Dictionary) EncodeWords(data []byte) ([]Word, error) {
+	mnemonic, err := d.encodeVerified(data)
+	if err != nil {
+		return nil, err
+	}
+
+	first := 0
+	if d.config.hasVersion {
+		first = 1
+	}
+
+	words := make([]Word, len(mnemonic))
+	for i, text := range mnemonic {
+		words[i] = Word{
+			Index:      i,
+			Text:       text,
+			IsChecksum: i >= first && i < first+d.checksumWords,
+		}
+	}
+
+	return words, nil
+}
+
+// DecodeWords decodes words created by EncodeWords.
+// Words could be in any order, they are placed by Index,
+// indexes should be exactly 0..N-1 without gaps and duplicates.
+// IsChecksum is ignored.
+func (d *Dictionary) DecodeWords(words []Word) ([]byte, error) {
+	mnemonic := make([]string, len(words))
+	seen := make([]bool, len(words))
+	for _, word := range words {
+		if word.Index < 0 || word.Index >= len(words) {
+			return nil, fmt.Errorf("word index %d out of range [0, %d)", word.Index, len(words))
+		}
+		if seen[word.Index] {
+			return nil, fmt.Errorf("duplicate word index %d", word.Index)
+		}
+
+		seen[word.Index] = true
+		mnemonic[word.Index] = word.Text
+	}
+
+	return d.decode(mnemonic)
+}
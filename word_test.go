@@ -0,0 +1,76 @@
+package recode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDic_EncodeWords(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	data := []byte{7, 255, 1, 255, 40, 128, 42, 42}
+	words, err := d.EncodeWords(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Word{
+		{Index: 0, Text: "festival", IsChecksum: true},
+		{Index: 1, Text: "among"},
+		{Index: 2, Text: "way"},
+		{Index: 3, Text: "lemon"},
+		{Index: 4, Text: "extra"},
+		{Index: 5, Text: "actor"},
+		{Index: 6, Text: "betray"},
+	}, words)
+
+	decoded, err := d.DecodeWords(words)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	// order does not matter
+	words[0], words[3] = words[3], words[0]
+	decoded, err = d.DecodeWords(words)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestDic_EncodeWords_Header(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary, WithVersion(1), WithChecksumWords(2)))
+	assert.NoError(t, err)
+
+	words, err := d.EncodeWords([]byte("nice!"))
+	assert.NoError(t, err)
+
+	checksum := []bool{}
+	for _, word := range words {
+		checksum = append(checksum, word.IsChecksum)
+	}
+	assert.Equal(t, []bool{false, true, true, false, false, false, false}, checksum)
+
+	decoded, err := d.DecodeWords(words)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("nice!"), decoded)
+}
+
+func TestDic_DecodeWords_Error(t *testing.T) {
+	d, err := asDictionary(NewDictionary(Bip39Dictionary))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		words []Word
+	}{
+		{"empty", []Word{}},
+		{"negative", []Word{{Index: -1, Text: "festival"}}},
+		{"gap", []Word{{Index: 0, Text: "festival"}, {Index: 2, Text: "among"}}},
+		{"duplicate", []Word{{Index: 0, Text: "festival"}, {Index: 0, Text: "among"}}},
+		{"unknown word", []Word{{Index: 0, Text: "WTF"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := d.DecodeWords(tt.words)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package recode
+
+import "hash/maphash"
+
+// wordIndex is an open addressing hash table from normalized word to its index.
+// Unlike wordToBits and bitsToInt maps it needs only one probe sequence
+// over flat slices and returns the index directly.
+type wordIndex struct {
+	seed maphash.Seed
+	// slots store entry index + 1, zero is an empty slot
+	slots []int32
+	mask  uint64
+	// keys and word indexes of entries, words go first, then aliases
+	keys []string
+	idxs []int32
+}
+
+// newWordIndex builds the index for normalized words and aliases,
+// mapped to normalized words, words should be already checked for duplicates
+func newWordIndex(words []string, aliases map[string]string, c config) *wordIndex {
+	entries := len(words) + len(aliases)
+	size := 1
+	// keep load factor under 1/2 for short probe sequences
+	for size < 2*entries {
+		size <<= 1
+	}
+
+	wi := &wordIndex{
+		seed:  maphash.MakeSeed(),
+		slots: make([]int32, size),
+		mask:  uint64(size - 1),
+		keys:  make([]string, 0, entries),
+		idxs:  make([]int32, 0, entries),
+	}
+
+	for i, word := range words {
+		wi.insert(c.normalize(word), i)
+	}
+	for alias, target := range aliases {
+		idx, _ := wi.lookup(target)
+		wi.insert(alias, idx)
+	}
+
+	return wi
+}
+
+func (wi *wordIndex) insert(key string, idx int) {
+	wi.keys = append(wi.keys, key)
+	wi.idxs = append(wi.idxs, int32(idx))
+
+	slot := maphash.String(wi.seed, key) & wi.mask
+	for wi.slots[slot] != 0 {
+		slot = (slot + 1) & wi.mask
+	}
+	wi.slots[slot] = int32(len(wi.keys))
+}
+
+// lookup returns index of already normalized key
+func (wi *wordIndex) lookup(key string) (int, bool) {
+	slot := maphash.String(wi.seed, key) & wi.mask
+	for {
+		entry := wi.slots[slot]
+		if entry == 0 {
+			return 0, false
+		}
+		if wi.keys[entry-1] == key {
+			return int(wi.idxs[entry-1]), true
+		}
+
+		slot = (slot + 1) & wi.mask
+	}
+}
@@ -0,0 +1,60 @@
+package recode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWordIndex(t *testing.T) {
+	for _, words := range [][]string{Bip39Dictionary, Slip39Dictionary, fruits, {"b", "a"}} {
+		d, err := asDictionary(NewDictionary(words))
+		assert.NoError(t, err)
+		indexed, err := asDictionary(NewDictionary(words, WithWordIndex()))
+		assert.NoError(t, err)
+
+		for i, word := range words {
+			idx, ok := indexed.lookupIdx(word)
+			assert.True(t, ok)
+			assert.Equal(t, i, idx)
+		}
+
+		_, ok := indexed.lookupIdx("not a word")
+		assert.False(t, ok)
+
+		for l := 0; l < 40; l++ {
+			data := randomBytes(t, l)
+			mnemonic, err := d.Encode(data)
+			assert.NoError(t, err)
+
+			got, err := indexed.Encode(data)
+			assert.NoError(t, err)
+			assert.Equal(t, mnemonic, got)
+
+			decoded, err := indexed.Decode(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decoded)
+		}
+	}
+}
+
+func TestWithWordIndex_CaseInsensitive(t *testing.T) {
+	d, err := asDictionary(NewDictionary([]string{"Foo", "bar", "FIZZ", "buzz"}, WithWordIndex(), WithCaseInsensitive()))
+	assert.NoError(t, err)
+
+	idx, ok := d.lookupIdx("fizz")
+	assert.True(t, ok)
+	assert.Equal(t, 2, idx)
+
+	data := []byte("nice!")
+	mnemonic, err := d.Encode(data)
+	assert.NoError(t, err)
+	for i := range mnemonic {
+		mnemonic[i] = strings.ToUpper(mnemonic[i])
+	}
+
+	decoded, err := d.Decode(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}